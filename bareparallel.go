@@ -0,0 +1,26 @@
+package gotestlooplint
+
+import (
+	"go/ast"
+
+	"github.com/life4/genesis/slices"
+)
+
+const bareParallelMessage = "t.Parallel() called directly in a loop instead of in a subtest. It marks the whole test as parallel and panics when called again on the next iteration"
+
+// Reports t.Parallel() calls made by the loops of a test rather than by the subtests the loops create
+func reportBareParallelCalls(pass *lintPass, options *Options) {
+	reportLoopCalls(pass, options, func(loopBody *ast.BlockStmt) []*ast.CallExpr {
+		subtestClosures := slices.Map(findAllSubtestCalls(pass, loopBody), func(runCall *ast.CallExpr) *ast.FuncLit {
+			return getSubtestClosure(pass, runCall)
+		})
+
+		return slices.Reject(findAllTestingTCalls(pass, loopBody, "Parallel"), func(parallelCall *ast.CallExpr) bool {
+			return slices.Any(subtestClosures, func(subtestClosure *ast.FuncLit) bool {
+				return subtestClosure != nil && isWithin(parallelCall, subtestClosure)
+			})
+		})
+	}, func(*ast.CallExpr) string {
+		return bareParallelMessage
+	})
+}
@@ -0,0 +1,111 @@
+package gotestlooplint
+
+import "fmt"
+
+// CaptureKind is the kind of closure capturing a loop variable
+type CaptureKind int
+
+const (
+	// CaptureParallel is a capture after t.Parallel() in a subtest
+	CaptureParallel CaptureKind = iota
+	// CaptureGoroutine is a capture in a goroutine launched from a subtest
+	CaptureGoroutine
+	// CaptureDefer is a capture in a deferred closure of a parallel subtest
+	CaptureDefer
+	// CaptureCleanup is a capture in a t.Cleanup closure of a parallel subtest
+	CaptureCleanup
+	// CaptureBenchmark is a capture in a b.RunParallel closure of a sub-benchmark
+	CaptureBenchmark
+	// CaptureFuzz is a capture in a f.Fuzz closure
+	CaptureFuzz
+	// CaptureGinkgoSpec is a capture in a Ginkgo It closure or one of its aliases
+	CaptureGinkgoSpec
+	// CaptureGinkgoTable is a capture in a Ginkgo DescribeTable closure
+	CaptureGinkgoTable
+	// CaptureGinkgoEntry is a capture in a Ginkgo table Entry closure
+	CaptureGinkgoEntry
+	// CaptureGinkgoSetup is a capture in a Ginkgo setup or teardown node such as BeforeEach
+	CaptureGinkgoSetup
+	// CaptureGinkgoContainer is a capture in a Ginkgo container such as Describe
+	CaptureGinkgoContainer
+	// CaptureGinkgoDeferCleanup is a capture in a Ginkgo DeferCleanup closure
+	CaptureGinkgoDeferCleanup
+	// CaptureHTTPHandler is a capture in an HTTP handler registered by a parallel subtest
+	CaptureHTTPHandler
+	// CaptureStoredClosure is a capture in a closure the loop stores for later, e.g. in a slice declared outside of it
+	CaptureStoredClosure
+
+	// The number of kinds, keep last
+	captureKindCount
+)
+
+// Stable names, used in the JSON and SARIF outputs
+var captureKindNames = map[CaptureKind]string{
+	CaptureParallel:           "parallel",
+	CaptureGoroutine:          "goroutine",
+	CaptureDefer:              "defer",
+	CaptureCleanup:            "cleanup",
+	CaptureBenchmark:          "benchmark",
+	CaptureFuzz:               "fuzz",
+	CaptureGinkgoSpec:         "ginkgo",
+	CaptureGinkgoTable:        "ginkgo-table",
+	CaptureGinkgoEntry:        "ginkgo-entry",
+	CaptureGinkgoSetup:        "ginkgo-setup",
+	CaptureGinkgoContainer:    "ginkgo-container",
+	CaptureGinkgoDeferCleanup: "ginkgo-defer-cleanup",
+	CaptureHTTPHandler:        "http-handler",
+	CaptureStoredClosure:      "stored-closure",
+}
+
+func (kind CaptureKind) String() string {
+	if name, ok := captureKindNames[kind]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("CaptureKind(%d)", int(kind))
+}
+
+// Short descriptions, used in the SARIF output and by Rules
+var captureKindDescriptions = map[CaptureKind]string{
+	CaptureParallel:           "Loop variable captured by a parallel subtest",
+	CaptureGoroutine:          "Loop variable captured by a goroutine launched from a subtest",
+	CaptureDefer:              "Loop variable captured by a deferred closure in a parallel subtest",
+	CaptureCleanup:            "Loop variable captured by a t.Cleanup closure in a parallel subtest",
+	CaptureBenchmark:          "Loop variable captured by a parallel benchmark",
+	CaptureFuzz:               "Loop variable captured by a fuzz function",
+	CaptureGinkgoSpec:         "Loop variable captured by a Ginkgo spec",
+	CaptureGinkgoTable:        "Loop variable captured by a Ginkgo table closure",
+	CaptureGinkgoEntry:        "Loop variable captured by a Ginkgo table entry closure",
+	CaptureGinkgoSetup:        "Loop variable captured by a Ginkgo setup or teardown node",
+	CaptureGinkgoContainer:    "Loop variable captured by a Ginkgo container",
+	CaptureGinkgoDeferCleanup: "Loop variable captured by a Ginkgo DeferCleanup closure",
+	CaptureHTTPHandler:        "Loop variable captured by an HTTP handler registered by a parallel subtest",
+	CaptureStoredClosure:      "Loop variable captured by a closure stored for later by the loop",
+}
+
+// RuleInfo describes the check reporting one kind of capture
+type RuleInfo struct {
+	// ID is the stable name of the kind, see CaptureKind.String
+	ID   string
+	Kind CaptureKind
+	// Description is a short description of the capture, also used in the SARIF output
+	Description string
+	// MessageFormat is the default format of the reported message, whose two %s verbs are replaced by the name of the
+	// loop variable
+	MessageFormat string
+}
+
+// Rules describes every kind of capture, in the order of the CaptureKind constants
+func Rules() []RuleInfo {
+	rules := make([]RuleInfo, 0, captureKindCount)
+	for kind := CaptureKind(0); kind < captureKindCount; kind++ {
+		rules = append(rules, RuleInfo{
+			ID:            kind.String(),
+			Kind:          kind,
+			Description:   captureKindDescriptions[kind],
+			MessageFormat: getMessageFormat(kind),
+		})
+	}
+
+	return rules
+}
@@ -1,10 +1,16 @@
 package main
 
 import (
+	"os"
+
 	"github.com/omertuc/gotestlooplint"
 	"golang.org/x/tools/go/analysis/singlechecker"
 )
 
 func main() {
+	if args, ok := cutSummaryFlag(os.Args[1:]); ok {
+		os.Exit(runWithSummary(args, os.Stderr))
+	}
+
 	singlechecker.Main(gotestlooplint.Analyzer)
 }
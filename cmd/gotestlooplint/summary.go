@@ -0,0 +1,120 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/life4/genesis/slices"
+	"github.com/omertuc/gotestlooplint"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+)
+
+const summaryFlag = "-summary"
+
+// Removes -summary from the arguments, singlechecker doesn't know about it
+func cutSummaryFlag(args []string) ([]string, bool) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+
+		if arg == summaryFlag || arg == "-"+summaryFlag {
+			return append(append([]string{}, args[:i]...), args[i+1:]...), true
+		}
+	}
+
+	return args, false
+}
+
+// Flags whose output or fixes runWithSummary doesn't produce, as it doesn't go through the diagnostics of the analyzer
+var summaryIncompatibleFlags = []string{"fix", "diff", "json", "c", "json-findings", "sarif", "warn-bare-parallel", "warn-setenv-parallel"}
+
+// Runs the analyzer through FindCaptures rather than singlechecker, which exits as soon as the last package is
+// analyzed, so that a summary of all the captures can be printed at the end. Returns the exit code, which matches the
+// one of singlechecker: 3 if there are captures
+func runWithSummary(args []string, output io.Writer) int {
+	flags := flag.NewFlagSet("gotestlooplint", flag.ContinueOnError)
+	flags.SetOutput(output)
+	gotestlooplint.Analyzer.Flags.VisitAll(func(analyzerFlag *flag.Flag) {
+		flags.Var(analyzerFlag.Value, analyzerFlag.Name, analyzerFlag.Usage)
+	})
+
+	// The flags of singlechecker that matter for loading packages are supported, the others are only parsed to be
+	// refused
+	tags := flags.String("tags", "", "comma-separated list of build tags")
+	tests := flags.Bool("test", true, "also analyze test packages")
+	flags.Bool("fix", false, "not supported with -summary")
+	flags.Bool("diff", false, "not supported with -summary")
+	flags.Bool("json", false, "not supported with -summary")
+	flags.Int("c", -1, "not supported with -summary")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	var incompatibleFlagError error
+	flags.Visit(func(setFlag *flag.Flag) {
+		if incompatibleFlagError == nil && slices.Contains(summaryIncompatibleFlags, setFlag.Name) {
+			incompatibleFlagError = fmt.Errorf("-%s can't be combined with %s", setFlag.Name, summaryFlag)
+		}
+	})
+	if incompatibleFlagError != nil {
+		fmt.Fprintf(output, "gotestlooplint: %v\n", incompatibleFlagError)
+		return 2
+	}
+
+	loadedPackages, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedTypesSizes,
+		Tests:      *tests,
+		BuildFlags: []string{"-tags=" + *tags},
+	}, flags.Args()...)
+	if err != nil {
+		fmt.Fprintf(output, "gotestlooplint: %v\n", err)
+		return 1
+	}
+
+	// With Tests the files of a package are also part of its test variant, so the same capture may be found twice
+	reportedPositions := map[string]bool{}
+	capturingFiles := map[string]bool{}
+	for _, loadedPackage := range loadedPackages {
+		if len(loadedPackage.Errors) > 0 {
+			packages.PrintErrors([]*packages.Package{loadedPackage})
+			return 1
+		}
+
+		findings, err := gotestlooplint.FindCaptures(&analysis.Pass{
+			Analyzer:   gotestlooplint.Analyzer,
+			Fset:       loadedPackage.Fset,
+			Files:      loadedPackage.Syntax,
+			Pkg:        loadedPackage.Types,
+			TypesInfo:  loadedPackage.TypesInfo,
+			TypesSizes: loadedPackage.TypesSizes,
+			ResultOf:   map[*analysis.Analyzer]interface{}{},
+			Report:     func(analysis.Diagnostic) {},
+		})
+		if err != nil {
+			fmt.Fprintf(output, "gotestlooplint: %v\n", err)
+			return 1
+		}
+
+		for _, finding := range findings {
+			position := loadedPackage.Fset.Position(finding.Pos)
+			if reportedPositions[position.String()] {
+				continue
+			}
+			reportedPositions[position.String()] = true
+			capturingFiles[position.Filename] = true
+
+			fmt.Fprintf(output, "%s: %s\n", position, finding.Message())
+		}
+	}
+
+	fmt.Fprintf(output, "gotestlooplint: %d captures found across %d files\n", len(reportedPositions), len(capturingFiles))
+
+	if len(reportedPositions) > 0 {
+		return 3
+	}
+	return 0
+}
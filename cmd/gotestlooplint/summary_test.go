@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Loads the ordering scenario of the analyzer's testdata, a GOPATH-style tree
+func chdirOrdering(t *testing.T) {
+	testdata, err := filepath.Abs(filepath.Join("..", "..", "testdata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("GOPATH", testdata)
+	t.Setenv("GO111MODULE", "off")
+
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(filepath.Join(testdata, "src", "ordering")); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(workingDirectory) })
+}
+
+func TestSummary(t *testing.T) {
+	chdirOrdering(t)
+
+	var output bytes.Buffer
+	if exitCode := runWithSummary([]string{"."}, &output); exitCode != 3 {
+		t.Errorf("exit code %d, want 3", exitCode)
+	}
+
+	// The captures of the package and of its test variant are only counted once
+	if !strings.HasSuffix(output.String(), "gotestlooplint: 5 captures found across 1 files\n") {
+		t.Errorf("unexpected output:\n%s", output.String())
+	}
+}
+
+func TestSummaryIncompatibleFlags(t *testing.T) {
+	chdirOrdering(t)
+
+	for _, args := range [][]string{{"-fix", "."}, {"-sarif", "gotestlooplint.sarif", "."}, {"-warn-bare-parallel", "."}} {
+		var output bytes.Buffer
+		if exitCode := runWithSummary(args, &output); exitCode != 2 || !strings.Contains(output.String(), "can't be combined with -summary") {
+			t.Errorf("%v: exit code %d, output:\n%s", args, exitCode, output.String())
+		}
+	}
+}
@@ -0,0 +1,429 @@
+package gotestlooplint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/life4/genesis/slices"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var (
+	goTestFailureMessageFormat             = "loop variable `%s` used directly inside parallel test closure. This could lead to tests not running as expected. Try aliasing `%s` to a variable outside the closure"
+	goroutineFailureMessageFormat          = "loop variable `%s` captured inside goroutine launched from test closure. This could lead to tests not running as expected. Try aliasing `%s` to a variable outside the closure"
+	cleanupFailureMessageFormat            = "loop variable `%s` captured inside t.Cleanup closure in test closure. This could lead to tests not running as expected. Try aliasing `%s` to a variable outside the closure"
+	deferFailureMessageFormat              = "loop variable `%s` captured inside deferred closure in test closure. This could lead to tests not running as expected. Try aliasing `%s` to a variable outside the closure"
+	benchmarkFailureMessageFormat          = "loop variable `%s` used directly inside parallel benchmark closure. This could lead to benchmarks not running as expected. Try aliasing `%s` to a variable outside the closure"
+	fuzzFailureMessageFormat               = "loop variable `%s` used directly inside fuzz closure. This could lead to fuzz tests not running as expected. Try aliasing `%s` to a variable outside the closure"
+	ginkgoFailureMessageFormat             = "loop variable `%s` used directly inside ginkgo It closure. This could lead to tests not running as expected. Try aliasing `%s` to a variable outside the closure"
+	ginkgoTableFailureMessageFormat        = "loop variable `%s` used directly inside ginkgo table closure. This could lead to tests not running as expected. Try aliasing `%s` to a variable outside the closure"
+	ginkgoEntryFailureMessageFormat        = "loop variable `%s` used directly inside ginkgo table entry closure. This could lead to tests not running as expected. Try aliasing `%s` to a variable outside the closure or passing it as an entry parameter"
+	ginkgoContainerFailureMessageFormat    = "loop variable `%s` used directly inside ginkgo container closure. This could lead to tests not running as expected. Try aliasing `%s` to a variable outside the closure"
+	ginkgoDeferCleanupFailureMessageFormat = "loop variable `%s` captured inside ginkgo DeferCleanup closure. This could lead to tests not running as expected. Try aliasing `%s` to a variable outside the closure or passing it as an argument of DeferCleanup"
+	storedClosureFailureMessageFormat      = "loop variable `%s` captured inside closure stored for later by the loop. This could lead to tests not running as expected. Try aliasing `%s` to a variable outside the closure"
+	httpHandlerFailureMessageFormat        = "loop variable `%s` captured inside HTTP handler registered by test closure. This could lead to tests not running as expected. Try aliasing `%s` to a variable outside the closure"
+	ginkgoSetupFailureMessageFormat        = "loop variable `%s` used directly inside ginkgo setup or teardown closure. This could lead to tests not running as expected. Try aliasing `%s` to a variable outside the closure"
+)
+
+// Finding is a loop variable captured by a closure that may run after the loop advanced
+type Finding struct {
+	// Variable is the name of the captured loop variable
+	Variable string
+	// Pos is the position where the closure uses the loop variable, i.e. of its identifier rather than of the
+	// enclosing expression, e.g. `tc` in `require.Equal(t, tc.expected, got)`
+	Pos token.Pos
+	// Kind is the kind of the capturing closure
+	Kind CaptureKind
+	// CallPos is the position of the call that makes the capture a problem, e.g. the t.Parallel() call of a parallel
+	// subtest, the go or defer statement call, the Ginkgo It call registering the spec, or the statement storing the
+	// closure
+	CallPos token.Pos
+	// DeclarationPos is the position where the loop declares the variable
+	DeclarationPos token.Pos
+	// RangeRole is "key" or "value" for the variables of range loops, e.g. `i` and `v` in `for i, v := range xs`,
+	// and empty for the variables of for loops
+	RangeRole string
+	// Subtest is the name of the subtest the capture is in as written in its t.Run call, e.g. `"valid input"` or
+	// `tc.name`. It's empty for captures outside of subtests and for names computed by other expressions
+	Subtest string
+
+	call    ast.Node
+	closure *ast.FuncLit
+	object  types.Object
+	// The body of the loop of the variable, set when the alias must go there rather than in the closure
+	loopBody *ast.BlockStmt
+	// The format of the message, which depends on the options of the analyzer finding the capture
+	messageFormat string
+	// The line of DeclarationPos, only set when another loop around or inside the loop of the variable has a variable
+	// of the same name
+	declarationLine int
+}
+
+func (finder *captureFinder) newFinding(identifier *ast.Ident, kind CaptureKind, call ast.Node) Finding {
+	object := finder.pass.TypesInfo.ObjectOf(identifier)
+
+	declarationLine := 0
+	if finder.shadowedLoopVariables[object] {
+		declarationLine = finder.pass.Fset.Position(object.Pos()).Line
+	}
+
+	return Finding{
+		Variable:        identifier.Name,
+		Pos:             identifier.Pos(),
+		Kind:            kind,
+		CallPos:         call.Pos(),
+		DeclarationPos:  object.Pos(),
+		RangeRole:       finder.rangeRoles[object],
+		Subtest:         finder.subtest,
+		call:            call,
+		object:          object,
+		messageFormat:   finder.options.getMessageFormat(kind),
+		declarationLine: declarationLine,
+	}
+}
+
+// Message describes the capture the same way the analyzer finding it reports it
+func (finding Finding) Message() string {
+	messageFormat := finding.messageFormat
+	if messageFormat == "" {
+		messageFormat = getMessageFormat(finding.Kind)
+	}
+
+	message := fmt.Sprintf(messageFormat, finding.Variable, finding.Variable)
+	if messageFormat != getMessageFormat(finding.Kind) {
+		// Custom messages are reported as they are, they may e.g. end with a link
+		return message
+	}
+
+	if finding.Subtest != "" {
+		message += fmt.Sprintf(" in subtest %s", finding.Subtest)
+	}
+	// Other loops declaring a variable of the same name are told apart by the line of the loop
+	switch {
+	case finding.RangeRole != "" && finding.declarationLine != 0:
+		message += fmt.Sprintf(". `%s` is the range %s of the loop on line %d", finding.Variable, finding.RangeRole, finding.declarationLine)
+	case finding.RangeRole != "":
+		message += fmt.Sprintf(". `%s` is the range %s", finding.Variable, finding.RangeRole)
+	case finding.declarationLine != 0:
+		message += fmt.Sprintf(". `%s` is the variable of the loop on line %d", finding.Variable, finding.declarationLine)
+	}
+
+	return message
+}
+
+type captureFinder struct {
+	pass     *lintPass
+	options  *Options
+	findings []Finding
+	// The roles of the variables of the range loops checked so far, see Finding.RangeRole
+	rangeRoles map[types.Object]string
+	// The name of the subtest being checked, see Finding.Subtest
+	subtest string
+	// Loop variables sharing their name with a variable of a loop around or inside their own loop
+	shadowedLoopVariables map[types.Object]bool
+	// The body of the loop being checked, see Finding.loopBody
+	loopBody *ast.BlockStmt
+}
+
+// Records which of the variables of a range loop is the key and which is the value
+func (finder *captureFinder) recordRangeRoles(rangeStatement *ast.RangeStmt) {
+	keyRole := "key"
+	if hasValuesOnly(finder.pass.TypesInfo.TypeOf(rangeStatement.X)) {
+		// The only variable of `for v := range ch` is parsed as the key
+		keyRole = "value"
+	}
+
+	finder.recordRangeRole(rangeStatement.Key, keyRole)
+	finder.recordRangeRole(rangeStatement.Value, "value")
+}
+
+// Records the variables of the loop sharing their name with the variables of the loops around it or inside it, e.g.
+// two nested `for i := ...` loops. Their findings tell where they're declared, as the name alone is ambiguous
+func (finder *captureFinder) recordShadowedLoopVariables(loopNode ast.Node, stack []ast.Node) {
+	otherLoopVariableNames := map[string]bool{}
+	recordLoop := func(otherLoopNode ast.Node) {
+		if otherLoopNode == loopNode {
+			return
+		}
+
+		for _, identifier := range getLoopVarsIdentifiers(otherLoopNode) {
+			otherLoopVariableNames[identifier.Name] = true
+		}
+	}
+
+	// The stack holds the enclosing nodes, including the loop itself
+	for _, enclosingNode := range stack {
+		recordLoop(enclosingNode)
+	}
+
+	ast.Inspect(getLoopBody(loopNode), func(descendantNode ast.Node) bool {
+		recordLoop(descendantNode)
+		return true
+	})
+
+	for _, identifier := range getLoopVarsIdentifiers(loopNode) {
+		if object := finder.pass.TypesInfo.ObjectOf(identifier); object != nil && otherLoopVariableNames[identifier.Name] {
+			finder.shadowedLoopVariables[object] = true
+		}
+	}
+}
+
+func (finder *captureFinder) recordRangeRole(expression ast.Expr, role string) {
+	identifier := exprToIdent(expression)
+	if isNilOrBlankIdent(identifier) {
+		return
+	}
+
+	if object := finder.pass.TypesInfo.ObjectOf(identifier); object != nil {
+		finder.rangeRoles[object] = role
+	}
+}
+
+// Checks whether ranging over the type yields values without keys, i.e. whether it's a channel or an iterator
+// function such as iter.Seq, whose yield function takes a single value
+func hasValuesOnly(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+
+	switch underlyingType := typ.Underlying().(type) {
+	case *types.Chan:
+		return true
+	case *types.Signature:
+		if underlyingType.Params().Len() != 1 {
+			return false
+		}
+
+		yield, ok := underlyingType.Params().At(0).Type().Underlying().(*types.Signature)
+		return ok && yield.Params().Len() == 1
+	default:
+		return false
+	}
+}
+
+// FindCaptures runs the same checks as Analyzer and returns the captures it finds instead of reporting them, so that
+// other tools can consume them without going through diagnostics. Captures ignored by a directive are left out.
+// Findings are sorted by position.
+func FindCaptures(pass *analysis.Pass) ([]Finding, error) {
+	return findCaptures(newLintPass(pass), &analyzerOptions)
+}
+
+// FindCapturesWithOptions is FindCaptures configured by the given options rather than by the flags of Analyzer, like
+// the analyzers created by NewAnalyzer
+func FindCapturesWithOptions(pass *analysis.Pass, options Options) ([]Finding, error) {
+	return findCaptures(newLintPass(pass), &options)
+}
+
+func findCaptures(pass *lintPass, options *Options) ([]Finding, error) {
+	skippedFiles := map[*token.File]bool{}
+	for _, file := range pass.Files {
+		if !options.Force && hasPerIterationLoopVars(pass.Pass, file) {
+			skippedFiles[pass.Fset.File(file.Pos())] = true
+		}
+	}
+
+	inspectorResult, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok {
+		// Passes of analyzers that don't require the inspect analyzer
+		inspectorResult = inspector.New(pass.Files)
+	}
+
+	finder := &captureFinder{
+		pass:                  pass,
+		options:               options,
+		rangeRoles:            map[types.Object]string{},
+		shadowedLoopVariables: map[types.Object]bool{},
+	}
+
+	// Only for and range statements share their variables between iterations. Functional iteration helpers such as
+	// slices.Map or slices.Each pass every element as a parameter of a new callback call, and closures capturing
+	// such parameters are safe
+	var err error
+	// The inspector visits every node of these types, including loops that are the statement of an *ast.LabeledStmt
+	inspectorResult.WithStack([]ast.Node{
+		(*ast.RangeStmt)(nil),
+		(*ast.ForStmt)(nil),
+	}, func(loopNode ast.Node, push bool, stack []ast.Node) bool {
+		if err != nil {
+			return false
+		}
+
+		if push && !skippedFiles[pass.Fset.File(loopNode.Pos())] {
+			err = finder.checkLoop(loopNode, stack)
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Findings are collected loop by loop and check by check, they're sorted so that the output only depends on where
+	// the captures are
+	sort.SliceStable(finder.findings, func(i, j int) bool {
+		position, otherPosition := pass.Fset.Position(finder.findings[i].Pos), pass.Fset.Position(finder.findings[j].Pos)
+		if position.Filename != otherPosition.Filename {
+			return position.Filename < otherPosition.Filename
+		}
+		return position.Offset < otherPosition.Offset
+	})
+
+	return finder.findings, nil
+}
+
+func (finder *captureFinder) report(finding Finding) {
+	if isIgnored(finder.pass.Pass, finder.options, finding.Pos) || slices.Contains(finder.options.IgnoredVariableNames, finding.Variable) {
+		return
+	}
+
+	finder.findings = append(finder.findings, finding)
+}
+
+func reportFindings(pass *lintPass, findings []Finding) {
+	// All the captures of a variable in a closure, or in the closures of a loop body, are fixed by the same alias, it's
+	// only suggested with the first of them so that applying every fix at once, e.g. with -fix, doesn't insert the
+	// alias more than once
+	type aliasKey struct {
+		block    *ast.BlockStmt
+		variable string
+	}
+	suggestedAliases := map[aliasKey]bool{}
+
+	for _, finding := range findings {
+		key := aliasKey{variable: finding.Variable}
+		if finding.Kind == CaptureParallel {
+			key.block, _ = finding.getAliasBlock()
+		}
+		reportFinding(pass, finding, !suggestedAliases[key])
+		suggestedAliases[key] = true
+	}
+}
+
+func reportFinding(pass *lintPass, finding Finding, suggestAlias bool) {
+	diagnostic := analysis.Diagnostic{
+		Pos:     finding.Pos,
+		Message: finding.Message(),
+	}
+
+	if finding.Kind == CaptureParallel {
+		if suggestAlias {
+			diagnostic.SuggestedFixes = getAliasLoopVariableFixes(pass, finding)
+		}
+		diagnostic.Related = []analysis.RelatedInformation{{
+			Pos:     finding.CallPos,
+			End:     finding.call.End(),
+			Message: "the closure becomes parallel here",
+		}}
+	}
+
+	diagnostic.Related = append(diagnostic.Related, analysis.RelatedInformation{
+		Pos:     finding.DeclarationPos,
+		Message: fmt.Sprintf("`%s` is declared here", finding.Variable),
+	})
+
+	pass.Report(diagnostic)
+}
+
+func getMessageFormat(kind CaptureKind) string {
+	switch kind {
+	case CaptureGoroutine:
+		return goroutineFailureMessageFormat
+	case CaptureDefer:
+		return deferFailureMessageFormat
+	case CaptureCleanup:
+		return cleanupFailureMessageFormat
+	case CaptureBenchmark:
+		return benchmarkFailureMessageFormat
+	case CaptureFuzz:
+		return fuzzFailureMessageFormat
+	case CaptureGinkgoSpec:
+		return ginkgoFailureMessageFormat
+	case CaptureGinkgoTable:
+		return ginkgoTableFailureMessageFormat
+	case CaptureGinkgoEntry:
+		return ginkgoEntryFailureMessageFormat
+	case CaptureGinkgoSetup:
+		return ginkgoSetupFailureMessageFormat
+	case CaptureGinkgoContainer:
+		return ginkgoContainerFailureMessageFormat
+	case CaptureGinkgoDeferCleanup:
+		return ginkgoDeferCleanupFailureMessageFormat
+	case CaptureHTTPHandler:
+		return httpHandlerFailureMessageFormat
+	case CaptureStoredClosure:
+		return storedClosureFailureMessageFormat
+	default:
+		return goTestFailureMessageFormat
+	}
+}
+
+// Returns the block the alias of a captured variable goes at the beginning of, along with what it is
+func (finding Finding) getAliasBlock() (*ast.BlockStmt, string) {
+	if finding.loopBody != nil {
+		return finding.loopBody, "loop body"
+	}
+
+	return finding.closure.Body, "closure"
+}
+
+// Suggests aliasing the loop variable as the first statement of the subtest closure, which is always before the
+// closure calls t.Parallel(), or of the loop body when the closure may be paused anywhere. Nothing is suggested when
+// the alias would redeclare a variable of the block, or change what the loop body assigns, e.g. `i++`
+func getAliasLoopVariableFixes(pass *lintPass, finding Finding) []analysis.SuggestedFix {
+	block, place := finding.getAliasBlock()
+	if len(block.List) == 0 || declaresVariable(block, finding.Variable) {
+		return nil
+	}
+
+	if finding.loopBody != nil && isAssigned(pass, finding.loopBody, finding.object) {
+		return nil
+	}
+
+	firstStatement := block.List[0]
+	indentation := strings.Repeat("\t", pass.Fset.Position(firstStatement.Pos()).Column-1)
+
+	return []analysis.SuggestedFix{{
+		Message: fmt.Sprintf("Alias `%s` at the beginning of the %s", finding.Variable, place),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     firstStatement.Pos(),
+			End:     firstStatement.Pos(),
+			NewText: []byte(fmt.Sprintf("%s := %s\n%s", finding.Variable, finding.Variable, indentation)),
+		}},
+	}}
+}
+
+// Checks whether the statements of a block declare a variable, type or constant of the given name in the scope of the
+// block itself, rather than in the scopes of nested blocks
+func declaresVariable(block *ast.BlockStmt, name string) bool {
+	isName := func(identifier *ast.Ident) bool { return identifier.Name == name }
+
+	return slices.Any(block.List, func(statement ast.Stmt) bool {
+		switch statement := statement.(type) {
+		case *ast.AssignStmt:
+			return statement.Tok == token.DEFINE && slices.Any(slices.Map(statement.Lhs, exprToIdent), func(identifier *ast.Ident) bool {
+				return identifier != nil && isName(identifier)
+			})
+		case *ast.DeclStmt:
+			declaration, ok := statement.Decl.(*ast.GenDecl)
+			return ok && slices.Any(declaration.Specs, func(spec ast.Spec) bool {
+				switch spec := spec.(type) {
+				case *ast.ValueSpec:
+					return slices.Any(spec.Names, isName)
+				case *ast.TypeSpec:
+					return isName(spec.Name)
+				default:
+					return false
+				}
+			})
+		default:
+			return false
+		}
+	})
+}
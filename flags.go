@@ -0,0 +1,53 @@
+package gotestlooplint
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A flag overriding a failure message format. Formats are validated when the flag is parsed, so that a malformed
+// format can't break reporting later on
+type messageFormatFlag struct {
+	format *string
+}
+
+func (f messageFormatFlag) String() string {
+	if f.format == nil {
+		return ""
+	}
+
+	return *f.format
+}
+
+func (f messageFormatFlag) Set(value string) error {
+	// Both verbs are replaced by the name of the loop variable
+	verbs := strings.ReplaceAll(value, "%%", "")
+	if strings.Count(verbs, "%") != 2 || strings.Count(verbs, "%s") != 2 {
+		return fmt.Errorf("message format %q must contain exactly two %%s verbs and no other verbs", value)
+	}
+
+	*f.format = value
+	return nil
+}
+
+// A repeatable flag, every occurrence appends its value to the list
+type appendFlag struct {
+	values *[]string
+}
+
+func (f appendFlag) String() string {
+	if f.values == nil {
+		return ""
+	}
+
+	return strings.Join(*f.values, ",")
+}
+
+func (f appendFlag) Set(value string) error {
+	if value == "" {
+		return fmt.Errorf("value must not be empty")
+	}
+
+	*f.values = append(*f.values, value)
+	return nil
+}
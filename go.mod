@@ -1,13 +1,13 @@
 module github.com/omertuc/gotestlooplint
 
-go 1.19
+go 1.22.0
 
 require (
 	github.com/life4/genesis v1.1.0
-	golang.org/x/tools v0.1.12
+	golang.org/x/tools v0.27.0
 )
 
 require (
-	golang.org/x/mod v0.6.0-dev.0.20220419223038-86c51ed26bb4 // indirect
-	golang.org/x/sys v0.0.0-20220722155257-8c9f86f7a55f // indirect
+	golang.org/x/mod v0.22.0 // indirect
+	golang.org/x/sync v0.9.0 // indirect
 )
@@ -1,182 +1,1130 @@
 package gotestlooplint
 
 import (
+	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/token"
 	"go/types"
+	"runtime/debug"
+	"strconv"
 	"strings"
 
 	"github.com/life4/genesis/slices"
 	"golang.org/x/tools/go/analysis"
-	"golang.org/x/tools/go/analysis/passes/inspect"
-	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/ast/astutil"
 )
 
 var (
-	goTestFailureMessageFormat = "loop variable `%s` used directly inside parallel test closure. This could lead to tests not running as expected. Try aliasing `%s` to a variable outside the closure"
-	ginkgoFailureMessageFormat = "loop variable `%s` used directly inside ginkgo It closure. This could lead to tests not running as expected. Try aliasing `%s` to a variable outside the closure"
+	// Specify is a documented alias of It, the F, P and X prefixes mark focused and pending specs
+	ginkgoSpecFunctionNames  = []string{"It", "Specify", "FIt", "PIt", "XIt"}
+	ginkgoTableFunctionNames = []string{"DescribeTable"}
+	// The F, P and X prefixes mark focused and pending entries
+	ginkgoEntryFunctionNames = []string{"Entry", "FEntry", "PEntry", "XEntry"}
+	ginkgoSetupFunctionNames = []string{"BeforeEach", "AfterEach", "JustBeforeEach", "JustAfterEach"}
+	// When and Context are aliases of Describe
+	ginkgoContainerFunctionNames = []string{"Describe", "Context", "When"}
+
+	// DeferCleanup isn't a node of the spec tree, so it's not part of ginkgoNodeFunctionNames. By isn't checked at all,
+	// since it runs its callback right away, while the loop is still on the same iteration
+	ginkgoDeferCleanupFunctionNames = []string{"DeferCleanup"}
+
+	ginkgoNodeFunctionNames = slices.Concat(ginkgoSpecFunctionNames, ginkgoTableFunctionNames, ginkgoEntryFunctionNames, ginkgoSetupFunctionNames, ginkgoContainerFunctionNames)
 )
 
-var Analyzer = &analysis.Analyzer{
-	Name:     "gotestlooplint",
-	Doc:      "gotestlooplint looks for loop var capture in parallel go tests or for loop var capture in regular Ginkgo tests",
-	Run:      findIgnoredTests,
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
-}
-
-func findIgnoredTests(pass *analysis.Pass) (interface{}, error) {
-	pass.ResultOf[inspect.Analyzer].(*inspector.Inspector).Preorder([]ast.Node{
-		(*ast.RangeStmt)(nil),
-		(*ast.ForStmt)(nil),
-	}, func(loopNode ast.Node) {
-		// recover panic
-		defer func() {
-			if r := recover(); r != nil {
-				pass.Reportf(loopNode.Pos(), "panic: %s\n", r)
-			}
-		}()
+// Methods of *testing.T whose arguments are skipped with -ignore-log-args
+var testingLogMethodNames = []string{"Log", "Logf", "Error", "Errorf", "Fatal", "Fatalf"}
 
-		checkAndReportLoop(pass, loopNode)
-		checkAndReportLoopGinkgo(pass, loopNode)
-	})
+const testifySuitePackagePath = "github.com/stretchr/testify/suite"
+
+// Types whose Go method runs its function argument in a new goroutine, by package path
+var goroutineGroupTypeNames = map[string]string{
+	"golang.org/x/sync/errgroup": "Group",
+	"sync":                       "WaitGroup",
+}
+
+// Functions of net/http registering handler functions. httptest servers take an http.Handler, so closures passed to
+// them are always wrapped in an http.HandlerFunc conversion
+var httpHandlerFunctionNames = []string{"HandlerFunc", "HandleFunc"}
+
+// Analyzer is configured through its flags. Use NewAnalyzer to configure an analyzer without changing global state
+var Analyzer = newAnalyzer(&analyzerOptions)
+
+func init() {
+	analyzerOptions.RegisterFlags(&Analyzer.Flags)
+}
+
+// A pass along with the types of the testing package, looked up by getTestingType once per pass as they're compared
+// against every call under every loop
+type lintPass struct {
+	*analysis.Pass
+	testingTypes map[string]types.Type
+}
+
+func newLintPass(pass *analysis.Pass) *lintPass {
+	return &lintPass{Pass: pass, testingTypes: map[string]types.Type{}}
+}
+
+func findIgnoredTests(pass *lintPass, options *Options) (interface{}, error) {
+	findings, err := findCaptures(pass, options)
+	if err != nil {
+		return nil, err
+	}
+
+	reportFindings(pass, findings)
+
+	if options.CheckGoTest && options.WarnBareParallel {
+		reportBareParallelCalls(pass, options)
+	}
+
+	if options.CheckGoTest && options.WarnSetenvParallel {
+		reportSetenvParallelCalls(pass, options)
+	}
+
+	if options.JSONFindings {
+		if err := writeJSONFindings(pass.Pass, findings); err != nil {
+			return nil, err
+		}
+	}
+
+	if options.SARIFPath != "" {
+		if err := writeSARIF(pass.Pass, findings, options.SARIFPath); err != nil {
+			return nil, err
+		}
+	}
 
 	return nil, nil
 }
 
-func checkFunction(pass *analysis.Pass, functionDeclaration *ast.FuncDecl) {
-	if !strings.HasPrefix(functionDeclaration.Name.Name, "Test") {
-		return
+func (finder *captureFinder) checkLoop(loopNode ast.Node, stack []ast.Node) (err error) {
+	pass := finder.pass
+
+	// Internal bugs shouldn't be reported as problems in the analyzed code
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("internal error while checking the loop at %s: %v\n%s", pass.Fset.Position(loopNode.Pos()), r, debug.Stack())
+		}
+	}()
+
+	if isIgnored(pass.Pass, finder.options, loopNode.Pos()) {
+		return nil
+	}
+
+	loopVarsIdentifiersObjects := getLoopNodeIdentifiersObjects(pass, loopNode)
+	if len(loopVarsIdentifiersObjects) == 0 {
+		// Loops without variables, e.g. `for cond { ... }`, have nothing to capture
+		return nil
+	}
+
+	if rangeStatement, ok := loopNode.(*ast.RangeStmt); ok {
+		finder.recordRangeRoles(rangeStatement)
+	}
+	finder.recordShadowedLoopVariables(loopNode, stack)
+
+	finder.loopBody = getLoopBody(loopNode)
+	calls := collectLoopCalls(pass, finder.options, finder.loopBody)
+
+	if finder.options.CheckGinkgo {
+		// Ginkgo specs are usually registered outside of test functions, e.g. in `var _ = Describe(...)`
+		finder.checkAndReportLoopGinkgo(loopVarsIdentifiersObjects, calls)
+		finder.checkAndReportLoopGinkgoNodes(loopVarsIdentifiersObjects, calls.ginkgoTableCalls, CaptureGinkgoTable)
+		// Unlike It closures, entry closures run as the body of the table they're passed to
+		finder.checkAndReportLoopGinkgoNodes(loopVarsIdentifiersObjects, calls.ginkgoEntryCalls, CaptureGinkgoEntry)
+		finder.checkAndReportLoopGinkgoNodes(loopVarsIdentifiersObjects, calls.ginkgoSetupCalls, CaptureGinkgoSetup)
+		finder.checkAndReportLoopGinkgoContainers(loopVarsIdentifiersObjects, calls)
+		finder.checkAndReportLoopGinkgoDeferCleanup(loopVarsIdentifiersObjects, calls)
+	}
+
+	if !finder.options.CheckGoTest {
+		return nil
+	}
+
+	if functionDeclaration := findEnclosingFunctionDeclaration(stack); functionDeclaration != nil &&
+		!checkFunction(pass, finder.options, functionDeclaration) {
+		return nil
+	}
+
+	finder.checkAndReportLoop(loopVarsIdentifiersObjects, calls)
+	finder.checkAndReportLoopBenchmark(loopVarsIdentifiersObjects, calls)
+	finder.checkAndReportLoopFuzz(loopVarsIdentifiersObjects, calls)
+	finder.checkAndReportLoopStoredClosures(loopVarsIdentifiersObjects, loopNode)
+
+	return nil
+}
+
+func findEnclosingFunctionDeclaration(stack []ast.Node) *ast.FuncDecl {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if functionDeclaration, ok := stack[i].(*ast.FuncDecl); ok {
+			return functionDeclaration
+		}
+	}
+
+	return nil
+}
+
+// Checks whether a function is a test, benchmark, fuzz test, example or one of the -test-prefix functions, or a test
+// helper with -check-helpers
+func checkFunction(pass *lintPass, options *Options, functionDeclaration *ast.FuncDecl) bool {
+	if options.CheckHelpers && isTestHelper(pass, functionDeclaration) {
+		return true
+	}
+
+	if !slices.Any(options.TestFunctionPrefixes, func(prefix string) bool { return strings.HasPrefix(functionDeclaration.Name.Name, prefix) }) {
+		return false
 	}
 
 	if functionDeclaration.Recv != nil {
-		// A method that happens to be named Test<Something> is not a test
-		return
+		// Methods of testify suites are run as subtests, any other method that happens to be named Test<Something>
+		// is not a test
+		return isTestifySuite(pass, pass.TypesInfo.TypeOf(functionDeclaration.Recv.List[0].Type))
 	}
+
+	return true
 }
 
-func isParallelFunctionClosure(pass *analysis.Pass, closure *ast.FuncLit) *token.Pos {
-	// Closure test
-	if parallelCall := findTestingTCalls(pass, closure.Body, "Parallel"); parallelCall != nil {
-		parallelCallPos := parallelCall.Pos()
-		return &parallelCallPos
+// Checks whether a function takes the test context, such as `func runCases(t *testing.T, cases []testCase)`
+func isTestHelper(pass *lintPass, functionDeclaration *ast.FuncDecl) bool {
+	return slices.Any(functionDeclaration.Type.Params.List, func(parameter *ast.Field) bool {
+		return isTestingType(pass, pass.TypesInfo.TypeOf(parameter.Type), "T")
+	})
+}
+
+// Returns the call making the closure parallel, either t.Parallel() or a helper calling it. Returns nil if there is
+// none, e.g. when the closure body is empty
+func isParallelFunctionClosure(pass *lintPass, closure *ast.FuncLit) *ast.CallExpr {
+	// A parallel nested subtest doesn't make its parent parallel, the parent t.Run call still waits for it to finish
+	isOwnCall := getOwnCallFilter(pass, closure)
+
+	// Closure test. The receiver is matched by its type rather than its name, so `st.Parallel()` in a
+	// `func(st *testing.T)` closure is found as well
+	if parallelCall, err := slices.Find(findAllTestingTCalls(pass, closure.Body, "Parallel"), isOwnCall); err == nil {
+		return parallelCall
+	}
+
+	// Closure test which calls a helper such as `markParallel(t)`
+	if parallelHelperCall, err := slices.Find(findParallelHelperCalls(pass, closure.Body), isOwnCall); err == nil {
+		return parallelHelperCall
 	}
 
 	return nil
 }
 
-func getLoopNodeIdentifiersObjects(pass *analysis.Pass, loopNode ast.Node) []types.Object {
-	return slices.Map(getLoopVarsIdentifiers(loopNode), pass.TypesInfo.ObjectOf)
+// Returns a filter accepting the calls made by the subtest closure itself rather than by its nested subtests
+func getOwnCallFilter(pass *lintPass, closure *ast.FuncLit) func(*ast.CallExpr) bool {
+	nestedSubtestClosures := slices.Map(findAllSubtestCalls(pass, closure.Body), func(runCall *ast.CallExpr) *ast.FuncLit {
+		return getSubtestClosure(pass, runCall)
+	})
+
+	return func(call *ast.CallExpr) bool {
+		return !slices.Any(nestedSubtestClosures, func(nestedSubtestClosure *ast.FuncLit) bool {
+			return nestedSubtestClosure != nil && isWithin(call, nestedSubtestClosure)
+		})
+	}
 }
 
-func checkAndReportLoop(pass *analysis.Pass, loopNode ast.Node) {
-	loopVarsIdentifiersObjects := getLoopNodeIdentifiersObjects(pass, loopNode)
+// Checks whether a call is a statement of its own directly in the block, e.g. `t.Parallel()` but not
+// `if cond { t.Parallel() }`
+func isTopLevelCall(block *ast.BlockStmt, call *ast.CallExpr) bool {
+	return slices.Any(block.List, func(statement ast.Stmt) bool {
+		expressionStatement, ok := statement.(*ast.ExprStmt)
+		return ok && expressionStatement.X == call
+	})
+}
 
-	runCall := findTestingTCalls(pass, getLoopBody(loopNode), "Run")
-	if runCall == nil {
-		return
+// Scans a tree for calls to same-package helper functions that are passed the test context and call t.Parallel()
+func findParallelHelperCalls(pass *lintPass, rootNode ast.Node) []*ast.CallExpr {
+	var matchingCallExpressions []*ast.CallExpr
+
+	ast.Inspect(rootNode, func(descendantNode ast.Node) bool {
+		callExpression, ok := descendantNode.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		callIdentifier, ok := callExpression.Fun.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		helper, ok := pass.TypesInfo.ObjectOf(callIdentifier).(*types.Func)
+		if !ok || helper.Pkg() != pass.Pkg {
+			return true
+		}
+
+		if !slices.Any(callExpression.Args, func(arg ast.Expr) bool { return isTestingType(pass, pass.TypesInfo.TypeOf(arg), "T") }) {
+			return true
+		}
+
+		// Only follow a single level of calls
+		helperDeclaration := findFunctionDeclaration(pass, helper)
+		if helperDeclaration != nil && helperDeclaration.Body != nil &&
+			findTestingTCalls(pass, helperDeclaration.Body, "Parallel") != nil {
+			matchingCallExpressions = append(matchingCallExpressions, callExpression)
+			return false
+		}
+
+		return true
+	})
+
+	return matchingCallExpressions
+}
+
+// Checks whether a call is a call of a same-package helper forwarding one of its parameters to a Ginkgo spec, such as
+// `registerSpec("description", func() { ... })` where registerSpec calls `It(description, body)`
+func isGinkgoSpecHelperCall(pass *lintPass, callExpression *ast.CallExpr) bool {
+	callIdentifier, ok := callExpression.Fun.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	helper, ok := pass.TypesInfo.ObjectOf(callIdentifier).(*types.Func)
+	if !ok || helper.Pkg() != pass.Pkg {
+		return false
+	}
+
+	// Only follow a single level of calls
+	helperDeclaration := findFunctionDeclaration(pass, helper)
+	if helperDeclaration == nil || helperDeclaration.Body == nil {
+		return false
+	}
+
+	// Variadic parameters are forwarded the same way, e.g. `It(description, args...)`
+	isParameter := func(arg ast.Expr) bool {
+		identifier, ok := arg.(*ast.Ident)
+		if !ok {
+			return false
+		}
+
+		parameter, ok := pass.TypesInfo.ObjectOf(identifier).(*types.Var)
+		return ok && isWithinPos(parameter.Pos(), helperDeclaration.Type.Params)
+	}
+
+	return len(findAllMatchingCalls(helperDeclaration.Body, func(specCall *ast.CallExpr) bool {
+		specIdentifier := getCallIdentifier(specCall)
+		return specIdentifier != nil && slices.Contains(ginkgoSpecFunctionNames, specIdentifier.Name) &&
+			isGinkgoIdentifier(pass, specIdentifier) && slices.Any(specCall.Args, isParameter)
+	})) > 0
+}
+
+func findFunctionDeclaration(pass *lintPass, function *types.Func) *ast.FuncDecl {
+	for _, file := range pass.Files {
+		for _, declaration := range file.Decls {
+			functionDeclaration, ok := declaration.(*ast.FuncDecl)
+			if ok && pass.TypesInfo.ObjectOf(functionDeclaration.Name) == function {
+				return functionDeclaration
+			}
+		}
+	}
+
+	return nil
+}
+
+// Blank identifiers are already left out by getLoopVarsIdentifiers, unresolved identifiers are dropped here so that
+// only real variables are compared against. Variables declared in the loop body, e.g. `tc := cases[i]`, are new on
+// every iteration and are never part of these
+func getLoopNodeIdentifiersObjects(pass *lintPass, loopNode ast.Node) []types.Object {
+	return slices.Reject(slices.Map(getLoopVarsIdentifiers(loopNode), pass.TypesInfo.ObjectOf), func(object types.Object) bool {
+		return object == nil
+	})
+}
+
+// Subtests created in nested loops are checked against the variables of this loop too, while each nested loop checks
+// its own variables when it's visited, so every capture is reported exactly once
+func (finder *captureFinder) checkAndReportLoop(loopVarsIdentifiersObjects []types.Object, calls loopCalls) {
+	// Every subtest is analyzed on its own, each with its own t.Parallel() call
+	for _, runCall := range calls.subtestCalls {
+		finder.checkAndReportSubtest(loopVarsIdentifiersObjects, runCall)
 	}
+}
 
-	closure := getSubtestClosure(runCall)
+func (finder *captureFinder) checkAndReportSubtest(loopVarsIdentifiersObjects []types.Object, runCall *ast.CallExpr) {
+	pass := finder.pass
+
+	closure := getSubtestClosure(pass, runCall)
 	if closure == nil {
 		return
 	}
 
+	finder.subtest = getSubtestName(pass, runCall)
+	defer func() { finder.subtest = "" }()
+
 	// Check if this is a parallel closure
-	parallelTokenPos := isParallelFunctionClosure(pass, closure)
-	if parallelTokenPos == nil {
+	parallelCall := isParallelFunctionClosure(pass, closure)
+
+	// Goroutines may run after the loop advanced regardless of whether the test is parallel
+	var checkedClosures []*ast.FuncLit
+	if finder.options.CheckGoroutine {
+		checkedClosures = append(checkedClosures, finder.checkAndReportSubtestGoroutines(loopVarsIdentifiersObjects, closure, parallelCall != nil)...)
+	}
+
+	// Deferred calls and cleanup functions of a subtest that isn't parallel run before t.Run returns, so only those of
+	// a parallel one run after the loop advanced
+	if parallelCall == nil {
 		return
 	}
 
-	// Find all usages of the loop variables in the closure
+	if finder.options.CheckDefer {
+		checkedClosures = append(checkedClosures, finder.checkAndReportSubtestDeferredCalls(loopVarsIdentifiersObjects, closure)...)
+	}
+
+	if finder.options.CheckCleanup {
+		checkedClosures = append(checkedClosures, finder.checkAndReportSubtestCleanups(loopVarsIdentifiersObjects, closure)...)
+	}
+
+	// Uses before an unconditional t.Parallel() call run before the closure is paused. When the call is nested, e.g.
+	// in an if statement or a loop, its position says nothing about what runs before it, so every use is reported
+	// With -strict every use is reported too, so that moving t.Parallel() up can't silently introduce a capture
+	parallelPos := closure.Body.Pos()
+	if !finder.options.Strict && isTopLevelCall(closure.Body, parallelCall) {
+		parallelPos = parallelCall.Pos()
+	}
+
+	reportCapture := func(identifier *ast.Ident) {
+		finding := finder.newFinding(identifier, CaptureParallel, parallelCall)
+		finding.closure = closure
+		if parallelPos == closure.Body.Pos() {
+			// The alias is suggested in the loop body, where it stays before t.Parallel() wherever the call goes
+			finding.loopBody = finder.loopBody
+		}
+		finder.report(finding)
+	}
+
+	// Aliases the closure starts with run before anything else in it, wherever it calls t.Parallel()
+	leadingAliases := getLeadingAliases(pass, loopVarsIdentifiersObjects, closure.Body)
+
+	// Closures called right where they're defined, e.g. `name := func() string { return tc.name }()`, run along with
+	// the code around them, unlike the closures of go and defer statements. Statements are visited before their calls
+	// and calls before their function, so both are known by the time the closure is visited
+	launchedCalls := map[*ast.CallExpr]bool{}
+	invokedClosures := map[*ast.FuncLit]bool{}
+
+	// Find all usages of the loop variables in the closure, including inside the loops of the closure itself. The
+	// variables of those loops are only checked when they're visited as loops of their own
 	ast.Inspect(closure, func(closureDescendantNode ast.Node) bool {
 		if closureDescendantNode == nil {
 			return true
 		}
 
-		if closureDescendantNode.Pos() <= *parallelTokenPos {
+		if descendantClosure, ok := closureDescendantNode.(*ast.FuncLit); ok && slices.Contains(checkedClosures, descendantClosure) {
+			// Already reported above as a goroutine, deferred or cleanup capture
+			return false
+		}
+
+		if statement, ok := closureDescendantNode.(ast.Stmt); ok && slices.Contains(leadingAliases, statement) {
+			return false
+		}
+
+		switch node := closureDescendantNode.(type) {
+		case *ast.GoStmt:
+			launchedCalls[node.Call] = true
+		case *ast.DeferStmt:
+			launchedCalls[node.Call] = true
+		case *ast.CallExpr:
+			if invokedClosure, ok := astutil.Unparen(node.Fun).(*ast.FuncLit); ok && !launchedCalls[node] {
+				invokedClosures[invokedClosure] = true
+			}
+		}
+
+		// The parameters, the body block and the t.Parallel() statement itself all start at or before the call, even
+		// when it's the first statement of the closure, so only what follows the call is past it
+		if closureDescendantNode.Pos() <= parallelPos {
+			// A nested closure defined before the parallel token captures the loop variable itself, so it reads the
+			// variable whenever it's called, possibly after t.Parallel()
+			if nestedClosure, ok := closureDescendantNode.(*ast.FuncLit); ok && nestedClosure != closure && !invokedClosures[nestedClosure] {
+				ast.Inspect(nestedClosure, func(nestedClosureDescendantNode ast.Node) bool {
+					if identifier := getLoopIdentifier(pass, loopVarsIdentifiersObjects, nestedClosureDescendantNode); identifier != nil {
+						reportCapture(identifier)
+						return false
+					}
+					return true
+				})
+				return false
+			}
+
+			// A pointer to the loop variable taken before the parallel token still points at the variable the loop
+			// keeps updating, e.g. `p := &tc` dereferenced after t.Parallel()
+			if identifier := getAddressedLoopIdentifier(pass, loopVarsIdentifiersObjects, closureDescendantNode); identifier != nil {
+				reportCapture(identifier)
+				return false
+			}
+
 			// This identifier is before the parallel token, so it is allowed to be used in the closure
 			return true
 		}
 
-		return checkAndReportLoopIdentifierObject(pass, loopVarsIdentifiersObjects, closureDescendantNode, goTestFailureMessageFormat)
+		if descendantCall, ok := closureDescendantNode.(*ast.CallExpr); ok && finder.options.IgnoreLogArgs && isTestingLogCall(pass, descendantCall) {
+			// The value is only printed, not used to decide what the test does
+			return false
+		}
+
+		identifier := getLoopIdentifier(pass, loopVarsIdentifiersObjects, closureDescendantNode)
+		if identifier == nil {
+			return true
+		}
+
+		reportCapture(identifier)
+		return false
 	})
 }
 
-func checkAndReportLoopGinkgo(pass *analysis.Pass, loopNode ast.Node) {
-	loopVarsIdentifiersObjects := getLoopNodeIdentifiersObjects(pass, loopNode)
+// Returns the statements a block starts with that alias loop variables under their own name, e.g. `tc := tc` or
+// `k, v := k, v`
+func getLeadingAliases(pass *lintPass, loopVarsIdentifiersObjects []types.Object, block *ast.BlockStmt) []ast.Stmt {
+	var aliases []ast.Stmt
+	for _, statement := range block.List {
+		assignment, ok := statement.(*ast.AssignStmt)
+		if !ok || assignment.Tok != token.DEFINE || len(assignment.Lhs) != len(assignment.Rhs) {
+			break
+		}
 
-	ginkgoItCall := findGinkgoItCalls(pass, getLoopBody(loopNode))
-	if ginkgoItCall == nil {
-		return
+		for i, value := range assignment.Rhs {
+			name, ok := assignment.Lhs[i].(*ast.Ident)
+			identifier := getLoopIdentifier(pass, loopVarsIdentifiersObjects, value)
+			if !ok || identifier == nil || identifier.Name != name.Name {
+				return aliases
+			}
+		}
+		aliases = append(aliases, assignment)
 	}
 
-	closure := getSubtestClosure(ginkgoItCall)
-	if closure == nil {
+	return aliases
+}
+
+// Checks the closures a subtest runs in goroutines and returns them, so that they're not checked again
+func (finder *captureFinder) checkAndReportSubtestGoroutines(loopVarsIdentifiersObjects []types.Object, closure *ast.FuncLit, isParallel bool) []*ast.FuncLit {
+	pass := finder.pass
+
+	// Goroutines may run after the loop advanced, unless the subtest waits for them through a group, e.g. with
+	// `defer wg.Done()` and `wg.Wait()`, which only helps when the subtest itself isn't paused by t.Parallel()
+	var checkedClosures []*ast.FuncLit
+	for _, goroutineCall := range findGoroutineCalls(closure) {
+		isWaited := slices.Any(findGoroutineGroupDoneCalls(pass, goroutineCall), func(doneCall *ast.CallExpr) bool {
+			return isWaitedFor(pass, closure, goroutineCall, doneCall)
+		})
+		if !isParallel && isWaited {
+			continue
+		}
+
+		goroutineClosure := goroutineCall.Fun.(*ast.FuncLit)
+		finder.checkAndReportClosure(loopVarsIdentifiersObjects, goroutineClosure, CaptureGoroutine, goroutineCall)
+		checkedClosures = append(checkedClosures, goroutineClosure)
+	}
+
+	// Functions passed to errgroup or sync.WaitGroup run in goroutines of their own, just like `go func() { ... }()`.
+	// Closures passed to sync.Once run right away, so they're only a problem after t.Parallel(), like any other use
+	for _, groupCall := range findGoroutineGroupCalls(pass, closure) {
+		if !isParallel && isWaitedFor(pass, closure, groupCall, groupCall) {
+			continue
+		}
+
+		for _, groupClosure := range getClosureArgs(groupCall) {
+			finder.checkAndReportClosure(loopVarsIdentifiersObjects, groupClosure, CaptureGoroutine, groupCall)
+			checkedClosures = append(checkedClosures, groupClosure)
+		}
+	}
+
+	// HTTP handlers are called by the goroutines of the server whenever it gets a request. A subtest that isn't
+	// parallel is done sending its requests before t.Run returns, so they only matter in parallel ones
+	if !isParallel {
+		return checkedClosures
+	}
+
+	for _, handlerCall := range findHTTPHandlerCalls(pass, closure) {
+		for _, handlerClosure := range getClosureArgs(handlerCall) {
+			finder.checkAndReportClosure(loopVarsIdentifiersObjects, handlerClosure, CaptureHTTPHandler, handlerCall)
+			checkedClosures = append(checkedClosures, handlerClosure)
+		}
+	}
+
+	return checkedClosures
+}
+
+// Checks the closures a subtest defers and returns them, so that they're not checked again
+func (finder *captureFinder) checkAndReportSubtestDeferredCalls(loopVarsIdentifiersObjects []types.Object, closure *ast.FuncLit) []*ast.FuncLit {
+	var checkedClosures []*ast.FuncLit
+	for _, deferredCall := range findDeferredCalls(closure) {
+		deferredClosure := deferredCall.Fun.(*ast.FuncLit)
+		finder.checkAndReportClosure(loopVarsIdentifiersObjects, deferredClosure, CaptureDefer, deferredCall)
+		checkedClosures = append(checkedClosures, deferredClosure)
+	}
+
+	return checkedClosures
+}
+
+// Checks the cleanup functions of a subtest and returns them, so that they're not checked again. Cleanup functions run
+// once the subtest and all of its own subtests finished
+func (finder *captureFinder) checkAndReportSubtestCleanups(loopVarsIdentifiersObjects []types.Object, closure *ast.FuncLit) []*ast.FuncLit {
+	var checkedClosures []*ast.FuncLit
+	for _, cleanupCall := range findAllTestingTCalls(finder.pass, closure.Body, "Cleanup") {
+		for _, cleanupClosure := range getClosureArgs(cleanupCall) {
+			finder.checkAndReportClosure(loopVarsIdentifiersObjects, cleanupClosure, CaptureCleanup, cleanupCall)
+			checkedClosures = append(checkedClosures, cleanupClosure)
+		}
+	}
+
+	return checkedClosures
+}
+
+func (finder *captureFinder) checkAndReportLoopBenchmark(loopVarsIdentifiersObjects []types.Object, calls loopCalls) {
+	pass := finder.pass
+
+	// Every benchmark run by the loop body is analyzed, each with every b.RunParallel() call of its closure
+	for _, runCall := range calls.benchmarkRunCalls {
+		closure := getSubtestClosure(pass, runCall)
+		if closure == nil {
+			continue
+		}
+
+		for _, runParallelCall := range findAllTestingCalls(pass, closure.Body, "B", "RunParallel") {
+			// The closure consuming the *testing.PB runs concurrently in multiple goroutines
+			finder.checkAndReportClosures(loopVarsIdentifiersObjects, getClosureArgs(runParallelCall), CaptureBenchmark, runParallelCall)
+		}
+	}
+}
+
+func (finder *captureFinder) checkAndReportLoopFuzz(loopVarsIdentifiersObjects []types.Object, calls loopCalls) {
+	if len(calls.fuzzCalls) == 0 {
 		return
 	}
 
-	// Find all usages of the loop variables in the closure
+	// The fuzz function runs for every input long after the loop finished
+	fuzzCall := calls.fuzzCalls[0]
+	finder.checkAndReportClosures(loopVarsIdentifiersObjects, getClosureArgs(fuzzCall), CaptureFuzz, fuzzCall)
+}
+
+func (finder *captureFinder) checkAndReportLoopGinkgo(loopVarsIdentifiersObjects []types.Object, calls loopCalls) {
+	// Specs nested inside the closure of another spec are covered by the check of the outer spec
+	var outerSpecCalls []*ast.CallExpr
+	for _, specCall := range calls.ginkgoSpecCalls {
+		outerSpecCalls = appendOuterCall(outerSpecCalls, specCall)
+	}
+
+	// Every spec registered by the loop body is analyzed, e.g. several It calls per iteration
+	for _, specCall := range outerSpecCalls {
+		closure := getSpecClosure(specCall)
+		if closure == nil {
+			continue
+		}
+
+		// Find all usages of the loop variables in the closure
+		finder.checkAndReportClosure(loopVarsIdentifiersObjects, closure, CaptureGinkgoSpec, specCall)
+	}
+}
+
+func (finder *captureFinder) checkAndReportClosures(loopVarsIdentifiersObjects []types.Object, closures []*ast.FuncLit, kind CaptureKind, call *ast.CallExpr) {
+	for _, closure := range closures {
+		finder.checkAndReportClosure(loopVarsIdentifiersObjects, closure, kind, call)
+	}
+}
+
+func (finder *captureFinder) checkAndReportClosure(loopVarsIdentifiersObjects []types.Object, closure *ast.FuncLit, kind CaptureKind, call ast.Node) {
 	ast.Inspect(closure, func(closureDescendantNode ast.Node) bool {
-		return checkAndReportLoopIdentifierObject(pass, loopVarsIdentifiersObjects, closureDescendantNode, ginkgoFailureMessageFormat)
+		return finder.checkAndReportLoopIdentifierObject(loopVarsIdentifiersObjects, closureDescendantNode, kind, call)
+	})
+}
+
+// Closures stored by the loop body in a variable declared outside the loop, e.g. `fns = append(fns, func() { ... })`,
+// are called once the loop advanced, or even once it's done, regardless of parallelism. Closures stored by other
+// closures of the loop body are left to the checks of those closures
+func (finder *captureFinder) checkAndReportLoopStoredClosures(loopVarsIdentifiersObjects []types.Object, loopNode ast.Node) {
+	pass := finder.pass
+
+	isOuterVariable := func(target ast.Expr) bool {
+		identifier := getRootIdentifier(target)
+		if identifier == nil {
+			return false
+		}
+
+		object := pass.TypesInfo.ObjectOf(identifier)
+		return object != nil && !isWithinPos(object.Pos(), loopNode)
+	}
+
+	ast.Inspect(getLoopBody(loopNode), func(descendantNode ast.Node) bool {
+		switch descendantNode := descendantNode.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			if len(descendantNode.Lhs) != len(descendantNode.Rhs) {
+				return true
+			}
+
+			for i, value := range descendantNode.Rhs {
+				if !isOuterVariable(descendantNode.Lhs[i]) {
+					continue
+				}
+
+				switch value := astutil.Unparen(value).(type) {
+				case *ast.FuncLit:
+					// `fns[i] = func() { ... }`, `s.callback = func() { ... }` or `fn = func() { ... }`
+					finder.checkAndReportClosure(loopVarsIdentifiersObjects, value, CaptureStoredClosure, descendantNode)
+				case *ast.CallExpr:
+					// `fns = append(fns, func() { ... })`
+					if isBuiltinCall(pass, value, "append") {
+						finder.checkAndReportClosures(loopVarsIdentifiersObjects, getClosureArgs(value), CaptureStoredClosure, value)
+					}
+				}
+			}
+		}
+
+		return true
 	})
 }
 
-func getSubtestClosure(runCall *ast.CallExpr) *ast.FuncLit {
-	closure, ok := runCall.Args[1].(*ast.FuncLit)
+// Returns the variable an assignment target is part of, e.g. `fns` in `fns[i]` or `s` in `s.callback`
+func getRootIdentifier(expression ast.Expr) *ast.Ident {
+	for {
+		switch typedExpression := astutil.Unparen(expression).(type) {
+		case *ast.Ident:
+			return typedExpression
+		case *ast.IndexExpr:
+			expression = typedExpression.X
+		case *ast.SelectorExpr:
+			expression = typedExpression.X
+		case *ast.StarExpr:
+			expression = typedExpression.X
+		default:
+			return nil
+		}
+	}
+}
+
+func isBuiltinCall(pass *lintPass, callExpression *ast.CallExpr, name string) bool {
+	identifier, ok := astutil.Unparen(callExpression.Fun).(*ast.Ident)
 	if !ok {
+		return false
+	}
+
+	builtin, ok := pass.TypesInfo.ObjectOf(identifier).(*types.Builtin)
+	return ok && builtin.Name() == name
+}
+
+// Closures passed to Ginkgo nodes such as table entries or setup nodes only run once the spec runs, long after
+// the loop advanced
+func (finder *captureFinder) checkAndReportLoopGinkgoNodes(loopVarsIdentifiersObjects []types.Object, nodeCalls []*ast.CallExpr, kind CaptureKind) {
+	for _, nodeCall := range nodeCalls {
+		finder.checkAndReportClosures(loopVarsIdentifiersObjects, getClosureArgs(nodeCall), kind, nodeCall)
+	}
+}
+
+// Container bodies run when the spec tree is built, unlike the closures of the nodes they contain which are checked
+// on their own
+func (finder *captureFinder) checkAndReportLoopGinkgoContainers(loopVarsIdentifiersObjects []types.Object, calls loopCalls) {
+	for _, containerCall := range calls.ginkgoContainerCalls {
+		for _, containerClosure := range getClosureArgs(containerCall) {
+			var nestedNodeClosures []*ast.FuncLit
+			for _, nestedNodeCall := range calls.ginkgoNodeCalls() {
+				if isWithin(nestedNodeCall, containerClosure.Body) {
+					nestedNodeClosures = append(nestedNodeClosures, getClosureArgs(nestedNodeCall)...)
+				}
+			}
+
+			ast.Inspect(containerClosure, func(closureDescendantNode ast.Node) bool {
+				if nestedNodeClosure, ok := closureDescendantNode.(*ast.FuncLit); ok && slices.Contains(nestedNodeClosures, nestedNodeClosure) {
+					return false
+				}
+
+				return finder.checkAndReportLoopIdentifierObject(loopVarsIdentifiersObjects, closureDescendantNode, CaptureGinkgoContainer, containerCall)
+			})
+		}
+	}
+}
+
+// DeferCleanup closures run once the spec finished. The arguments of `DeferCleanup(fn, args...)` are evaluated right
+// away, so passing the loop variables as arguments is safe. Cleanups registered inside the closures of other nodes in
+// the loop are covered by the checks of those nodes
+func (finder *captureFinder) checkAndReportLoopGinkgoDeferCleanup(loopVarsIdentifiersObjects []types.Object, calls loopCalls) {
+	var nodeClosures []*ast.FuncLit
+	for _, nodeCall := range calls.ginkgoNodeCalls() {
+		nodeClosures = append(nodeClosures, getClosureArgs(nodeCall)...)
+	}
+
+	for _, deferCleanupCall := range calls.ginkgoDeferCleanupCalls {
+		if slices.Any(nodeClosures, func(nodeClosure *ast.FuncLit) bool { return isWithin(deferCleanupCall, nodeClosure) }) {
+			continue
+		}
+
+		finder.checkAndReportClosures(loopVarsIdentifiersObjects, getClosureArgs(deferCleanupCall), CaptureGinkgoDeferCleanup, deferCleanupCall)
+	}
+}
+
+func getClosureArgs(call *ast.CallExpr) []*ast.FuncLit {
+	var closures []*ast.FuncLit
+	for _, arg := range call.Args {
+		if closure, ok := arg.(*ast.FuncLit); ok {
+			closures = append(closures, closure)
+		}
+	}
+	return closures
+}
+
+// Ginkgo specs accept decorators before or after the closure, such as `It("description", Label("x"), func() { ... })`
+// or `It("description", func(ctx SpecContext) { ... }, NodeTimeout(time.Second))`, so the closure isn't necessarily
+// the second argument. Its parameters don't matter. Pending specs such as `It("description")` have no closure at all
+func getSpecClosure(specCall *ast.CallExpr) *ast.FuncLit {
+	closures := getClosureArgs(specCall)
+	if len(closures) == 0 {
 		return nil
 	}
-	return closure
+	return closures[0]
 }
 
-func checkAndReportLoopIdentifierObject(pass *analysis.Pass, loopVarsIdentifiersObjects []types.Object, node ast.Node, message string) bool {
+// The closure of `t.Run(name, func(t *testing.T) { ... })` is its second argument. The name (the first argument) is
+// evaluated before t.Run even starts, so loop variables used to compute it are safe and it's never scanned, unless
+// there's no closure in the canonical position, in which case the first function literal argument is used
+func getSubtestClosure(pass *lintPass, runCall *ast.CallExpr) *ast.FuncLit {
+	if len(runCall.Args) >= 2 {
+		switch closure := runCall.Args[1].(type) {
+		case *ast.FuncLit:
+			return closure
+		case *ast.Ident:
+			// `fn := func(t *testing.T) { ... }` followed by `t.Run(name, fn)` captures just like passing the literal
+			// directly. Subtests built by calls such as `t.Run(name, makeSubtest(tc))` get the loop variables by value,
+			// and so do method values such as `t.Run(name, tc.run)`, which copy their receiver when they're evaluated
+			if resolvedClosure := resolveLocalClosure(pass, closure); resolvedClosure != nil {
+				return resolvedClosure
+			}
+		}
+	}
+
+	// Malformed or unusual calls, e.g. with swapped arguments
+	closures := getClosureArgs(runCall)
+	if len(closures) == 0 {
+		return nil
+	}
+	return closures[0]
+}
+
+// Returns the name of the subtest as written in `t.Run(name, ...)` when it's a constant, such as a string literal, or a
+// selector such as `tc.name`, which is usually enough to find the failing case. Other expressions return ""
+func getSubtestName(pass *lintPass, runCall *ast.CallExpr) string {
+	if len(runCall.Args) == 0 {
+		return ""
+	}
+
+	nameExpression := astutil.Unparen(runCall.Args[0])
+	if value := pass.TypesInfo.Types[nameExpression].Value; value != nil && value.Kind() == constant.String {
+		return strconv.Quote(constant.StringVal(value))
+	}
+
+	if selector, ok := nameExpression.(*ast.SelectorExpr); ok {
+		if _, ok := selector.X.(*ast.Ident); ok {
+			return types.ExprString(selector)
+		}
+	}
+
+	return ""
+}
+
+// Resolves an identifier to the function literal its variable was declared with, i.e. `fn := func() { ... }` or
+// `var fn = func() { ... }`
+func resolveLocalClosure(pass *lintPass, identifier *ast.Ident) *ast.FuncLit {
+	variable, ok := pass.TypesInfo.ObjectOf(identifier).(*types.Var)
+	if !ok {
+		return nil
+	}
+
+	file := findFile(pass.Pass, variable.Pos())
+	if file == nil {
+		return nil
+	}
+
+	path, _ := astutil.PathEnclosingInterval(file, variable.Pos(), variable.Pos())
+	if len(path) < 2 {
+		return nil
+	}
+
+	declaringIdentifier, ok := path[0].(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	var values []ast.Expr
+	var names []ast.Expr
+	switch declaration := path[1].(type) {
+	case *ast.AssignStmt:
+		if declaration.Tok != token.DEFINE {
+			return nil
+		}
+		names, values = declaration.Lhs, declaration.Rhs
+	case *ast.ValueSpec:
+		names, values = slices.Map(declaration.Names, func(name *ast.Ident) ast.Expr { return name }), declaration.Values
+	default:
+		return nil
+	}
+
+	if len(names) != len(values) {
+		return nil
+	}
+
+	if isReassigned(pass, file, variable) {
+		// The variable may hold a different function by the time it's called
+		return nil
+	}
+
+	for i, name := range names {
+		if name == declaringIdentifier {
+			closure, _ := values[i].(*ast.FuncLit)
+			return closure
+		}
+	}
+
+	return nil
+}
+
+// Checks whether a variable is assigned anywhere after its declaration, including through a pointer to it
+func isReassigned(pass *lintPass, file *ast.File, variable *types.Var) bool {
+	isVariable := func(expression ast.Expr) bool {
+		identifier, ok := astutil.Unparen(expression).(*ast.Ident)
+		return ok && pass.TypesInfo.Uses[identifier] == variable
+	}
+
+	reassigned := false
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			// Redeclarations such as `fn, err := ...` use the variable rather than define it
+			reassigned = reassigned || slices.Any(node.Lhs, isVariable)
+		case *ast.UnaryExpr:
+			reassigned = reassigned || (node.Op == token.AND && isVariable(node.X))
+		}
+		return !reassigned
+	})
+
+	return reassigned
+}
+
+// Checks whether a variable is assigned, or incremented or decremented, anywhere under a node
+func isAssigned(pass *lintPass, root ast.Node, variable types.Object) bool {
+	isVariable := func(expression ast.Expr) bool {
+		identifier, ok := astutil.Unparen(expression).(*ast.Ident)
+		return ok && pass.TypesInfo.Uses[identifier] == variable
+	}
+
+	assigned := false
+	ast.Inspect(root, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			assigned = assigned || slices.Any(node.Lhs, isVariable)
+		case *ast.IncDecStmt:
+			assigned = assigned || isVariable(node.X)
+		}
+		return !assigned
+	})
+
+	return assigned
+}
+
+// Every use of every loop variable is reported, e.g. both `k` and `v` of `for k, v := range m`, in the order they
+// appear in the closure
+func (finder *captureFinder) checkAndReportLoopIdentifierObject(loopVarsIdentifiersObjects []types.Object, node ast.Node, kind CaptureKind, call ast.Node) bool {
+	if identifier := getLoopIdentifier(finder.pass, loopVarsIdentifiersObjects, node); identifier != nil {
+		finder.report(finder.newFinding(identifier, kind, call))
+		// Identifiers have no children, so this never hides the uses of other loop variables in sibling nodes
+		return false
+	}
+
+	return true
+}
+
+// Returns the node if it's an identifier referring to one of the loop variables, nil otherwise. Callers walk every
+// node of a closure, so indirect uses such as `&tc`, `tc.field` or `tc[0]` are found through their `tc` identifier, and
+// so is the loop index in `items[i]`
+func getLoopIdentifier(pass *lintPass, loopVarsIdentifiersObjects []types.Object, node ast.Node) *ast.Ident {
 	if identifier, ok := node.(*ast.Ident); ok {
-		// Compare against all loop variable objects
+		// Compare against all loop variable objects. Identifiers the type checker couldn't resolve have no object and
+		// never match. Files excluded by build tags aren't part of the pass at all
 		identifierObject := pass.TypesInfo.ObjectOf(identifier)
+		if identifierObject == nil {
+			return nil
+		}
 
 		if slices.Any(loopVarsIdentifiersObjects, func(loopVarObject types.Object) bool {
 			return identifierObject == loopVarObject
 		}) {
-			name := identifier.Name
-			pass.Reportf(identifier.Pos(), message, name, name)
-			return false
+			return identifier
 		}
 	}
 
-	return true
+	return nil
 }
 
-// Scans a tree for method calls t.<methodName>() calls where t is the test context (t *testing.T)
-func findTestingTCalls(pass *analysis.Pass, rootNode ast.Node, methodName string) *ast.CallExpr {
-	var matchingCallExpression *ast.CallExpr
+// Returns the loop variable identifier whose address the node takes, such as `tc` in `&tc` or `&tc.field`, nil
+// otherwise
+func getAddressedLoopIdentifier(pass *lintPass, loopVarsIdentifiersObjects []types.Object, node ast.Node) *ast.Ident {
+	unaryExpression, ok := node.(*ast.UnaryExpr)
+	if !ok || unaryExpression.Op != token.AND {
+		return nil
+	}
 
-	ast.Inspect(rootNode, func(descendantNode ast.Node) bool {
-		callExpression, ok := descendantNode.(*ast.CallExpr)
+	operand := astutil.Unparen(unaryExpression.X)
+	for {
+		selector, ok := operand.(*ast.SelectorExpr)
 		if !ok {
+			break
+		}
+		operand = astutil.Unparen(selector.X)
+	}
+
+	return getLoopIdentifier(pass, loopVarsIdentifiersObjects, operand)
+}
+
+// Scans a tree for calls of function literals that are launched as goroutines, i.e. `go func() { ... }()`
+func findGoroutineCalls(rootNode ast.Node) []*ast.CallExpr {
+	return findClosureCalls(rootNode, func(node ast.Node) *ast.CallExpr {
+		if goStatement, ok := node.(*ast.GoStmt); ok {
+			return goStatement.Call
+		}
+		return nil
+	})
+}
+
+// Scans a tree for calls of function literals that are deferred, i.e. `defer func() { ... }()`
+func findDeferredCalls(rootNode ast.Node) []*ast.CallExpr {
+	return findClosureCalls(rootNode, func(node ast.Node) *ast.CallExpr {
+		if deferStatement, ok := node.(*ast.DeferStmt); ok {
+			return deferStatement.Call
+		}
+		return nil
+	})
+}
+
+// Scans a tree for calls launching goroutines through a group, i.e. `g.Go(func() error { ... })` where g is an
+// *errgroup.Group or `wg.Go(func() { ... })` where wg is a *sync.WaitGroup
+func findGoroutineGroupCalls(pass *lintPass, rootNode ast.Node) []*ast.CallExpr {
+	return findAllMatchingCalls(rootNode, func(callExpression *ast.CallExpr) bool {
+		return isGoroutineGroupCall(pass, callExpression, "Go")
+	})
+}
+
+// Checks whether a call is a call of the given method of one of the goroutine group types, e.g. `wg.Wait()`
+func isGoroutineGroupCall(pass *lintPass, callExpression *ast.CallExpr, methodName string) bool {
+	selector, ok := callExpression.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != methodName {
+		return false
+	}
+
+	selection := pass.TypesInfo.Selections[selector]
+	if selection == nil || selection.Kind() != types.MethodVal {
+		return false
+	}
+
+	receiverType := selection.Recv()
+	if pointerType, ok := receiverType.(*types.Pointer); ok {
+		receiverType = pointerType.Elem()
+	}
+
+	namedType, ok := receiverType.(*types.Named)
+	if !ok || namedType.Obj().Pkg() == nil {
+		return false
+	}
+
+	typeName, ok := goroutineGroupTypeNames[namedType.Obj().Pkg().Path()]
+	return ok && namedType.Obj().Name() == typeName
+}
+
+// Scans a goroutine for the calls signalling a sync.WaitGroup it's done, e.g. `defer wg.Done()`
+func findGoroutineGroupDoneCalls(pass *lintPass, goroutineCall *ast.CallExpr) []*ast.CallExpr {
+	return findAllMatchingCalls(goroutineCall.Fun, func(callExpression *ast.CallExpr) bool {
+		return isGoroutineGroupCall(pass, callExpression, "Done")
+	})
+}
+
+// Checks whether a subtest waits for the group of a call, e.g. of `wg.Go(...)` or `wg.Done()`, after the given
+// launching call, i.e. calls `wg.Wait()` once the goroutine started
+func isWaitedFor(pass *lintPass, closure *ast.FuncLit, launchingCall *ast.CallExpr, groupCall *ast.CallExpr) bool {
+	getGroup := func(groupCall *ast.CallExpr) types.Object {
+		identifier := getRootIdentifier(groupCall.Fun.(*ast.SelectorExpr).X)
+		if identifier == nil {
+			return nil
+		}
+		return pass.TypesInfo.ObjectOf(identifier)
+	}
+
+	group := getGroup(groupCall)
+	if group == nil {
+		return false
+	}
+
+	return len(findAllMatchingCalls(closure.Body, func(callExpression *ast.CallExpr) bool {
+		return callExpression.Pos() > launchingCall.End() && isGoroutineGroupCall(pass, callExpression, "Wait") && getGroup(callExpression) == group
+	})) > 0
+}
+
+// Scans a tree for calls registering HTTP handler functions, i.e. `http.HandlerFunc(func(w, r) { ... })`,
+// `mux.HandleFunc(pattern, func(w, r) { ... })` or `http.HandleFunc(pattern, func(w, r) { ... })`
+func findHTTPHandlerCalls(pass *lintPass, rootNode ast.Node) []*ast.CallExpr {
+	return findAllMatchingCalls(rootNode, func(callExpression *ast.CallExpr) bool {
+		callIdentifier := getCallIdentifier(callExpression)
+		if callIdentifier == nil {
+			return false
+		}
+
+		// Covers the HandlerFunc type, the HandleFunc function and the HandleFunc method of *http.ServeMux
+		object := pass.TypesInfo.ObjectOf(callIdentifier)
+		return object != nil && object.Pkg() != nil && object.Pkg().Path() == "net/http" &&
+			slices.Contains(httpHandlerFunctionNames, object.Name())
+	})
+}
+
+// Scans a tree for the calls that getCall extracts from statements whose called function is a function literal
+func findClosureCalls(rootNode ast.Node, getCall func(ast.Node) *ast.CallExpr) []*ast.CallExpr {
+	var closureCalls []*ast.CallExpr
+
+	ast.Inspect(rootNode, func(descendantNode ast.Node) bool {
+		call := getCall(descendantNode)
+		if call == nil {
 			return true
 		}
 
-		switch callExpressionFunction := callExpression.Fun.(type) {
-		case *ast.SelectorExpr:
-			switch callExpressionFunctionX := callExpressionFunction.X.(type) {
-			case *ast.Ident:
-				if pass.TypesInfo.ObjectOf(callExpressionFunctionX).Type().String() == "*testing.T" &&
-					callExpressionFunction.Sel.Name == methodName {
-					matchingCallExpression = callExpression
-					return false
-				}
-			}
+		if _, ok := call.Fun.(*ast.FuncLit); ok {
+			closureCalls = append(closureCalls, call)
 		}
 
 		return true
 	})
 
-	return matchingCallExpression
+	return closureCalls
+}
+
+// Scans a tree for method calls t.<methodName>() calls where t is the test context (t *testing.T)
+func findTestingTCalls(pass *lintPass, rootNode ast.Node, methodName string) *ast.CallExpr {
+	return findTestingCalls(pass, rootNode, "T", methodName)
+}
+
+// Scans a tree for method calls x.<methodName>() calls where x is a testing context of type *testing.<typeName>,
+// e.g. *testing.B for benchmarks
+func findTestingCalls(pass *lintPass, rootNode ast.Node, typeName string, methodName string) *ast.CallExpr {
+	matchingCallExpressions := findAllTestingCalls(pass, rootNode, typeName, methodName)
+	if len(matchingCallExpressions) == 0 {
+		return nil
+	}
+
+	return matchingCallExpressions[0]
+}
+
+// Like findTestingTCalls, but returns all matching calls
+func findAllTestingTCalls(pass *lintPass, rootNode ast.Node, methodName string) []*ast.CallExpr {
+	return findAllTestingCalls(pass, rootNode, "T", methodName)
+}
+
+// Like findTestingCalls, but returns all matching calls. Calls nested inside the arguments of a matching call are
+// not returned, as they're covered by the analysis of the outer call
+func findAllTestingCalls(pass *lintPass, rootNode ast.Node, typeName string, methodName string) []*ast.CallExpr {
+	return findAllMatchingCalls(rootNode, func(callExpression *ast.CallExpr) bool {
+		return isTestingCall(pass, callExpression, typeName, methodName)
+	})
+}
+
+// Scans a tree for subtests, either t.Run() calls or Run() calls of testify suites, e.g. `s.Run(name, func() { ... })`
+func findAllSubtestCalls(pass *lintPass, rootNode ast.Node) []*ast.CallExpr {
+	return findAllMatchingCalls(rootNode, func(callExpression *ast.CallExpr) bool {
+		return isTestingCall(pass, callExpression, "T", "Run") || isTestifySuiteCall(pass, callExpression, "Run")
+	})
 }
 
-// Scans a tree for Ginkgo It method calls
-func findGinkgoItCalls(pass *analysis.Pass, rootNode ast.Node) *ast.CallExpr {
-	var matchingCallExpression *ast.CallExpr
+// Scans a tree for the calls accepted by isMatch, without descending into the matched calls
+func findAllMatchingCalls(rootNode ast.Node, isMatch func(*ast.CallExpr) bool) []*ast.CallExpr {
+	var matchingCallExpressions []*ast.CallExpr
 
 	ast.Inspect(rootNode, func(descendantNode ast.Node) bool {
 		callExpression, ok := descendantNode.(*ast.CallExpr)
@@ -184,30 +1132,179 @@ func findGinkgoItCalls(pass *analysis.Pass, rootNode ast.Node) *ast.CallExpr {
 			return true
 		}
 
-		var callIdentifier *ast.Ident
-		switch callExpressionFunction := callExpression.Fun.(type) {
-		case *ast.SelectorExpr:
-			// This is when ginkgo is imported regularly, i.e. the call looks something like `ginkgo.It`
-			callIdentifier = callExpressionFunction.Sel
-		case *ast.Ident:
-			// This is when ginkgo is imported as wildcard, i.e. the call looks something like `It`
-			callIdentifier = callExpressionFunction
-		default:
-			return true
-		}
-
-		if callIdentifier != nil && callIdentifier.Name == "It" && isGinkgoIdentifier(pass, callIdentifier) {
-			matchingCallExpression = callExpression
+		if isMatch(callExpression) {
+			matchingCallExpressions = append(matchingCallExpressions, callExpression)
 			return false
 		}
 
 		return true
 	})
 
-	return matchingCallExpression
+	return matchingCallExpressions
+}
+
+// Checks whether a call is x.<methodName>() where x is a testing context of type *testing.<typeName>
+func isTestingCall(pass *lintPass, callExpression *ast.CallExpr, typeName string, methodName string) bool {
+	selector, ok := callExpression.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	if selector.Sel.Name != methodName {
+		return false
+	}
+
+	// Resolving the type of the whole receiver expression covers aliases like `tt := t`, struct fields like `s.t` as
+	// well as calls returning the context like `s.T()`
+	if isTestingType(pass, pass.TypesInfo.TypeOf(selector.X), typeName) {
+		return true
+	}
+
+	// Methods promoted from an embedded context, e.g. `s.Run` where s embeds *testing.T
+	selection := pass.TypesInfo.Selections[selector]
+	return selection != nil && selection.Kind() == types.MethodVal &&
+		slices.Any(getEmbeddedFieldTypes(selection), func(fieldType types.Type) bool {
+			return isTestingType(pass, fieldType, typeName)
+		})
+}
+
+// Returns the types of the embedded fields a promoted method is selected through, outermost first
+func getEmbeddedFieldTypes(selection *types.Selection) []types.Type {
+	fieldTypes := []types.Type{}
+	currentType := selection.Recv()
+	for _, index := range selection.Index()[:len(selection.Index())-1] {
+		if pointerType, ok := currentType.Underlying().(*types.Pointer); ok {
+			currentType = pointerType.Elem()
+		}
+
+		structType, ok := currentType.Underlying().(*types.Struct)
+		if !ok {
+			break
+		}
+
+		currentType = structType.Field(index).Type()
+		fieldTypes = append(fieldTypes, currentType)
+	}
+
+	return fieldTypes
+}
+
+// Checks whether a call logs its arguments through the test context, e.g. `t.Logf("case %s", tc.name)`
+func isTestingLogCall(pass *lintPass, callExpression *ast.CallExpr) bool {
+	return slices.Any(testingLogMethodNames, func(methodName string) bool {
+		return isTestingCall(pass, callExpression, "T", methodName)
+	})
+}
+
+// Checks whether a call is a call of the given method of testify's suite.Suite, possibly promoted through embedding
+func isTestifySuiteCall(pass *lintPass, callExpression *ast.CallExpr, methodName string) bool {
+	selector, ok := callExpression.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	selection := pass.TypesInfo.Selections[selector]
+	return selection != nil && selection.Kind() == types.MethodVal && isTestifySuiteObject(selection.Obj(), methodName)
+}
+
+// Checks whether a type is a testify suite, i.e. embeds suite.Suite and so has its T() method
+func isTestifySuite(pass *lintPass, typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+
+	method, _, _ := types.LookupFieldOrMethod(typ, true, pass.Pkg, "T")
+	return isTestifySuiteObject(method, "T")
+}
+
+func isTestifySuiteObject(object types.Object, name string) bool {
+	function, ok := object.(*types.Func)
+	return ok && function.Name() == name && function.Pkg() != nil && function.Pkg().Path() == testifySuitePackagePath
+}
+
+func isTestingType(pass *lintPass, typ types.Type, typeName string) bool {
+	if typ == nil {
+		return false
+	}
+
+	testingType, ok := pass.testingTypes[typeName]
+	if !ok {
+		testingType = getTestingType(pass.Pkg, typeName)
+		pass.testingTypes[typeName] = testingType
+	}
+
+	return testingType != nil && types.Identical(typ, testingType)
+}
+
+// Looks up *testing.<typeName> through the imports of the analyzed package, which works regardless of the name the
+// testing package is imported under. Indirect imports are searched as well, since the context may come from a
+// dependency, e.g. `s.T()` of a testify suite. Returns nil if the package doesn't depend on testing
+func getTestingType(pkg *types.Package, typeName string) types.Type {
+	visitedPackages := map[*types.Package]bool{}
+	pendingPackages := pkg.Imports()
+	for len(pendingPackages) > 0 {
+		importedPackage := pendingPackages[0]
+		pendingPackages = pendingPackages[1:]
+		if visitedPackages[importedPackage] {
+			continue
+		}
+		visitedPackages[importedPackage] = true
+
+		if importedPackage.Path() != "testing" {
+			pendingPackages = append(pendingPackages, importedPackage.Imports()...)
+			continue
+		}
+
+		if typeObject, ok := importedPackage.Scope().Lookup(typeName).(*types.TypeName); ok {
+			return types.NewPointer(typeObject.Type())
+		}
+	}
+
+	return nil
+}
+
+func getCallIdentifier(callExpression *ast.CallExpr) *ast.Ident {
+	switch callExpressionFunction := callExpression.Fun.(type) {
+	case *ast.SelectorExpr:
+		// This is when ginkgo is imported regularly, i.e. the call looks something like `ginkgo.It`
+		return callExpressionFunction.Sel
+	case *ast.Ident:
+		// This is when ginkgo is imported as wildcard, i.e. the call looks something like `It`
+		return callExpressionFunction
+	default:
+		return nil
+	}
 }
 
-func isGinkgoIdentifier(pass *analysis.Pass, identifier *ast.Ident) bool {
-	packagePath := pass.TypesInfo.ObjectOf(identifier).Pkg().Path()
+func isGinkgoIdentifier(pass *lintPass, identifier *ast.Ident) bool {
+	object := pass.TypesInfo.ObjectOf(identifier)
+	if object == nil || object.Pkg() == nil {
+		// Unresolved identifiers and universe scope objects can't come from Ginkgo
+		return false
+	}
+
+	return isGinkgoPackage(object.Pkg())
+}
+
+// Checks whether a call is x.<methodName>() where x is the testing context returned by Ginkgo's GinkgoT(), e.g.
+// `GinkgoT().Cleanup(func() { ... })`
+func isGinkgoTCall(pass *lintPass, callExpression *ast.CallExpr, methodName string) bool {
+	selector, ok := callExpression.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != methodName {
+		return false
+	}
+
+	// Resolving the type of the receiver covers both direct calls and contexts stored in a variable, e.g.
+	// `t := GinkgoT()`
+	receiverType, ok := pass.TypesInfo.TypeOf(selector.X).(*types.Named)
+	return ok && isGinkgoPackage(receiverType.Obj().Pkg())
+}
+
+func isGinkgoPackage(pkg *types.Package) bool {
+	if pkg == nil {
+		return false
+	}
+
+	packagePath := pkg.Path()
 	return packagePath == "github.com/onsi/ginkgo/v2" || packagePath == "github.com/onsi/ginkgo"
 }
@@ -0,0 +1,40 @@
+package gotestlooplint
+
+import (
+	"go/ast"
+	"go/parser"
+	"testing"
+)
+
+func parseCall(t *testing.T, source string) *ast.CallExpr {
+	expression, err := parser.ParseExpr(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return expression.(*ast.CallExpr)
+}
+
+// Malformed t.Run calls, which don't type check, have no closure rather than panicking
+func TestGetSubtestClosureMalformed(t *testing.T) {
+	for _, source := range []string{`t.Run()`, `t.Run("name")`, `t.Run(args...)`} {
+		if closure := getSubtestClosure(nil, parseCall(t, source)); closure != nil {
+			t.Errorf("%s: got a closure", source)
+		}
+	}
+}
+
+// Calls with swapped arguments, which don't type check either, fall back to their first closure argument
+func TestGetSubtestClosureSwappedArgs(t *testing.T) {
+	runCall := parseCall(t, `t.Run(func(t *testing.T) {}, "name")`)
+	if closure := getSubtestClosure(nil, runCall); closure != runCall.Args[0] {
+		t.Errorf("got closure %v, want the first argument", closure)
+	}
+}
+
+// Nodes other than for and range statements have no loop variables rather than panicking
+func TestGetLoopVarsIdentifiersUnexpectedNode(t *testing.T) {
+	if identifiers := getLoopVarsIdentifiers(parseCall(t, `t.Run("name", fn)`)); len(identifiers) != 0 {
+		t.Errorf("got loop variables %v", identifiers)
+	}
+}
@@ -0,0 +1,569 @@
+package gotestlooplint_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/omertuc/gotestlooplint"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/packages"
+)
+
+// Every scenario is a package of testdata/src, whose `// want` comments are the expected diagnostics
+func TestAnalyzer(t *testing.T) {
+	scenarios := []string{
+		"parallel",
+		"goroutine",
+		"deferred",
+		"cleanup",
+		"benchmark",
+		"fuzz",
+		"stored",
+		"ginkgo",
+		"dot",
+		"testify",
+		"tags",
+		"disabled",
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario, func(t *testing.T) {
+			analysistest.Run(t, analysistest.TestData(), gotestlooplint.Analyzer, scenario)
+		})
+	}
+}
+
+// Every module targets a different Go version, code targeting Go 1.22 or later through its go.mod or a build
+// constraint isn't checked
+func TestGoVersions(t *testing.T) {
+	for _, module := range []string{"go121", "go122"} {
+		t.Run(module, func(t *testing.T) {
+			analysistest.Run(t, filepath.Join(analysistest.TestData(), module), gotestlooplint.Analyzer, "./...")
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	testCases := []struct {
+		scenario string
+		setup    func(*gotestlooplint.Options)
+	}{
+		{"helpers", func(options *gotestlooplint.Options) { options.CheckHelpers = true }},
+		{"logargs", func(options *gotestlooplint.Options) { options.IgnoreLogArgs = true }},
+		{"ignorevar", func(options *gotestlooplint.Options) { options.IgnoredVariableNames = []string{"ctx"} }},
+		{"bareparallel", func(options *gotestlooplint.Options) { options.WarnBareParallel = true }},
+		{"strict", func(options *gotestlooplint.Options) { options.Strict = true }},
+		{"ginkgohelpers", func(options *gotestlooplint.Options) { options.FollowGinkgoHelpers = true }},
+		{"setenv", func(options *gotestlooplint.Options) { options.WarnSetenvParallel = true }},
+		{"nogoroutine", func(options *gotestlooplint.Options) { options.CheckGoroutine = false }},
+		{"nodefer", func(options *gotestlooplint.Options) { options.CheckDefer = false }},
+		{"nocleanup", func(options *gotestlooplint.Options) { options.CheckCleanup = false }},
+		{"nogotest", func(options *gotestlooplint.Options) { options.CheckGoTest = false }},
+		{"noginkgo", func(options *gotestlooplint.Options) { options.CheckGinkgo = false }},
+		{"nochecks", func(options *gotestlooplint.Options) { options.CheckGoTest, options.CheckGinkgo = false, false }},
+		{"testprefix", func(options *gotestlooplint.Options) {
+			options.TestFunctionPrefixes = append(options.TestFunctionPrefixes, "Scenario")
+		}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.scenario, func(t *testing.T) {
+			options := gotestlooplint.DefaultOptions()
+			testCase.setup(&options)
+			analysistest.Run(t, analysistest.TestData(), gotestlooplint.NewAnalyzer(options), testCase.scenario)
+		})
+	}
+}
+
+// Message formats set through the flags of Analyzer replace the default messages
+func TestMessageFlags(t *testing.T) {
+	formats := map[string]string{
+		"gotest-message": "`%s` is shared by the subtests of the loop, see https://wiki.example.com/loopvar#%s",
+		"ginkgo-message": "`%s` is shared by the specs of the loop, alias `%s` first",
+	}
+	for name, format := range formats {
+		defaultFormat := gotestlooplint.Analyzer.Flags.Lookup(name).Value.String()
+		if err := gotestlooplint.Analyzer.Flags.Set(name, format); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = gotestlooplint.Analyzer.Flags.Set(name, defaultFormat) })
+	}
+
+	analysistest.Run(t, analysistest.TestData(), gotestlooplint.Analyzer, "message")
+}
+
+// Formats whose verbs aren't exactly two %s are rejected when parsed rather than breaking the messages
+func TestMalformedMessageFlags(t *testing.T) {
+	formats := []string{
+		"loop variable %s is shared",
+		"loop variable %s is shared by %d subtests, alias %s",
+		"loop variable %s is shared, alias %v",
+	}
+	for _, name := range []string{"gotest-message", "ginkgo-message"} {
+		defaultFormat := gotestlooplint.Analyzer.Flags.Lookup(name).Value.String()
+		for _, format := range formats {
+			if err := gotestlooplint.Analyzer.Flags.Set(name, format); err == nil {
+				t.Errorf("-%s accepted %q", name, format)
+			}
+		}
+
+		if format := gotestlooplint.Analyzer.Flags.Lookup(name).Value.String(); format != defaultFormat {
+			t.Errorf("-%s changed to %q", name, format)
+		}
+	}
+}
+
+// The fixed packages must match their .golden files, and once every fix is applied they must still compile and have
+// nothing left to report but the captures without a fix
+func TestSuggestedFixes(t *testing.T) {
+	testCases := []struct {
+		scenario string
+		setup    func(*gotestlooplint.Options)
+	}{
+		{"fix", func(*gotestlooplint.Options) {}},
+		{"fixstrict", func(options *gotestlooplint.Options) { options.Strict = true }},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.scenario, func(t *testing.T) {
+			options := gotestlooplint.DefaultOptions()
+			testCase.setup(&options)
+			analyzer := gotestlooplint.NewAnalyzer(options)
+
+			results := analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), analyzer, testCase.scenario)
+			analysistest.Run(t, writeFixedPackage(t, results, testCase.scenario), analyzer, testCase.scenario)
+		})
+	}
+}
+
+// Writes the files of a scenario with every suggested fix applied to a GOPATH of its own, applying the edits
+// suggested for both the package and its test variant only once like -fix does. The `// want` comments of the fixed
+// files are dropped, since the captures they expect are gone
+func writeFixedPackage(t *testing.T, results []*analysistest.Result, scenario string) string {
+	type insertion struct {
+		offset int
+		text   string
+	}
+	fileInsertions := map[string]map[insertion]bool{}
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			for _, fix := range diagnostic.SuggestedFixes {
+				for _, edit := range fix.TextEdits {
+					if edit.End != edit.Pos {
+						t.Fatalf("%v: fix %q replaces code rather than inserting it", result.Pass.Fset.Position(edit.Pos), fix.Message)
+					}
+
+					position := result.Pass.Fset.Position(edit.Pos)
+					if fileInsertions[position.Filename] == nil {
+						fileInsertions[position.Filename] = map[insertion]bool{}
+					}
+					fileInsertions[position.Filename][insertion{position.Offset, string(edit.NewText)}] = true
+				}
+			}
+		}
+	}
+
+	gopath := t.TempDir()
+	dir := filepath.Join(gopath, "src", scenario)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(analysistest.TestData(), "src", scenario, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if insertions := fileInsertions[file]; len(insertions) > 0 {
+			// Inserting from the end of the file keeps the offsets of the insertions before valid
+			var sortedInsertions []insertion
+			for insertion := range insertions {
+				sortedInsertions = append(sortedInsertions, insertion)
+			}
+			sort.Slice(sortedInsertions, func(i, j int) bool {
+				if sortedInsertions[i].offset != sortedInsertions[j].offset {
+					return sortedInsertions[i].offset > sortedInsertions[j].offset
+				}
+				return sortedInsertions[i].text > sortedInsertions[j].text
+			})
+
+			for _, insertion := range sortedInsertions {
+				content = append(content[:insertion.offset:insertion.offset], append([]byte(insertion.text), content[insertion.offset:]...)...)
+			}
+			content = wantCommentPattern.ReplaceAll(content, nil)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, filepath.Base(file)), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return gopath
+}
+
+var wantCommentPattern = regexp.MustCompile(`[ \t]*// want .*`)
+
+// Reports every finding of the given function prefixed by its kind, failing if they're not sorted by file and offset
+func newFindingsAnalyzer(findCaptures func(*analysis.Pass) ([]gotestlooplint.Finding, error)) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:     "findings",
+		Doc:      "reports the findings of FindCaptures",
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			findings, err := findCaptures(pass)
+			if err != nil {
+				return nil, err
+			}
+
+			for i, finding := range findings {
+				if i > 0 {
+					previous, current := pass.Fset.Position(findings[i-1].Pos), pass.Fset.Position(finding.Pos)
+					if previous.Filename > current.Filename || previous.Filename == current.Filename && previous.Offset > current.Offset {
+						return nil, fmt.Errorf("findings aren't sorted: %v before %v", previous, current)
+					}
+				}
+				pass.Reportf(finding.Pos, "%s: %s", finding.Kind, finding.Message())
+			}
+
+			return nil, nil
+		},
+	}
+}
+
+func TestFindCaptures(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), newFindingsAnalyzer(gotestlooplint.FindCaptures), "findings", "ordering")
+}
+
+func TestFindCapturesWithOptions(t *testing.T) {
+	options := gotestlooplint.DefaultOptions()
+	options.IgnoredVariableNames = []string{"ctx"}
+	analysistest.Run(t, analysistest.TestData(), newFindingsAnalyzer(func(pass *analysis.Pass) ([]gotestlooplint.Finding, error) {
+		return gotestlooplint.FindCapturesWithOptions(pass, options)
+	}), "ignorevar")
+}
+
+// The SARIF document has a rule for every kind, and results with the essentials code scanning needs
+func TestSARIF(t *testing.T) {
+	options := gotestlooplint.DefaultOptions()
+	options.SARIFPath = filepath.Join(t.TempDir(), "gotestlooplint.sarif")
+	analysistest.Run(t, analysistest.TestData(), gotestlooplint.NewAnalyzer(options), "ordering")
+
+	content, err := os.ReadFile(options.SARIFPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var document struct {
+		Version string
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string
+					Rules []struct{ ID string }
+				}
+			}
+			Results []struct {
+				RuleID    string
+				Message   struct{ Text string }
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct{ URI string }
+						Region           struct{ StartLine int }
+					}
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(content, &document); err != nil {
+		t.Fatal(err)
+	}
+
+	if document.Version != "2.1.0" || len(document.Runs) != 1 || document.Runs[0].Tool.Driver.Name != "gotestlooplint" {
+		t.Fatalf("unexpected document:\n%s", content)
+	}
+
+	ruleIDs := map[string]bool{}
+	for _, rule := range document.Runs[0].Tool.Driver.Rules {
+		ruleIDs[rule.ID] = true
+	}
+	if len(ruleIDs) != len(gotestlooplint.Rules()) {
+		t.Errorf("got %d rules, want %d", len(ruleIDs), len(gotestlooplint.Rules()))
+	}
+
+	results := document.Runs[0].Results
+	if len(results) != 5 {
+		t.Fatalf("got %d results, want 5:\n%s", len(results), content)
+	}
+
+	for _, result := range results {
+		if !ruleIDs[result.RuleID] || result.Message.Text == "" || len(result.Locations) != 1 {
+			t.Errorf("incomplete result %+v", result)
+			continue
+		}
+
+		physicalLocation := result.Locations[0].PhysicalLocation
+		if !strings.HasSuffix(physicalLocation.ArtifactLocation.URI, "ordering/ordering_test.go") || physicalLocation.Region.StartLine <= 0 {
+			t.Errorf("unexpected location %+v", physicalLocation)
+		}
+	}
+}
+
+// Parallel captures point at the t.Parallel() call and every capture points at the declaration of the loop variable
+func TestRelatedInformation(t *testing.T) {
+	for _, result := range analysistest.Run(t, analysistest.TestData(), gotestlooplint.Analyzer, "related") {
+		for _, diagnostic := range result.Diagnostics {
+			if len(diagnostic.Related) != 2 {
+				t.Fatalf("%v: got %d related positions, want 2", result.Pass.Fset.Position(diagnostic.Pos), len(diagnostic.Related))
+			}
+
+			parallelCall, declaration := diagnostic.Related[0], diagnostic.Related[1]
+			if source := getSource(t, result.Pass.Fset, parallelCall.Pos, parallelCall.End); source != "t.Parallel()" {
+				t.Errorf("%v: related position at %q, want the t.Parallel() call", result.Pass.Fset.Position(diagnostic.Pos), source)
+			}
+
+			// The captured identifier and its declaration share the name, and loops are declared on lines of their own
+			variable := regexp.MustCompile("`(\\w+)`").FindStringSubmatch(diagnostic.Message)[1]
+			declarationLine := getLine(t, result.Pass.Fset, declaration.Pos)
+			if !strings.HasPrefix(getSource(t, result.Pass.Fset, declaration.Pos, declaration.Pos+token.Pos(len(variable))), variable) ||
+				!strings.HasPrefix(strings.TrimSpace(declarationLine), "for ") {
+				t.Errorf("%v: declaration position on %q", result.Pass.Fset.Position(diagnostic.Pos), declarationLine)
+			}
+		}
+	}
+}
+
+func getSource(t *testing.T, fset *token.FileSet, pos, end token.Pos) string {
+	content, err := os.ReadFile(fset.Position(pos).Filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content[fset.Position(pos).Offset:fset.Position(end).Offset])
+}
+
+func getLine(t *testing.T, fset *token.FileSet, pos token.Pos) string {
+	content, err := os.ReadFile(fset.Position(pos).Filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return strings.Split(string(content), "\n")[fset.Position(pos).Line-1]
+}
+
+// A panic while checking a loop is returned as an error of the run rather than reported as a problem of the code
+func TestInternalError(t *testing.T) {
+	checkedPackages := 0
+	for _, pkg := range loadTestdata(t, "related") {
+		// The package itself has no files, only its test variant has, and the test main package has no loops
+		if pkg.Name != "related" || len(pkg.Syntax) == 0 {
+			continue
+		}
+		checkedPackages++
+
+		var diagnostics []analysis.Diagnostic
+		pass := newPass(pkg, func(diagnostic analysis.Diagnostic) { diagnostics = append(diagnostics, diagnostic) })
+		// Resolving the loop variables panics without type information
+		pass.TypesInfo = nil
+
+		_, err := gotestlooplint.NewAnalyzer(gotestlooplint.DefaultOptions()).Run(pass)
+		if err == nil || !strings.Contains(err.Error(), "internal error") {
+			t.Errorf("%s: got error %v, want an internal error", pkg.ID, err)
+		}
+		if len(diagnostics) > 0 {
+			t.Errorf("%s: got diagnostics %v", pkg.ID, diagnostics)
+		}
+	}
+
+	if checkedPackages == 0 {
+		t.Fatal("no package to check")
+	}
+}
+
+// When the driver doesn't tell which Go version the code targets, e.g. without module information, loops are checked
+// as if they shared their variables between iterations
+func TestUnknownGoVersion(t *testing.T) {
+	countFindings := func(passes []*analysis.Pass) int {
+		count := 0
+		for _, pass := range passes {
+			findings, err := gotestlooplint.FindCaptures(pass)
+			if err != nil {
+				t.Fatal(err)
+			}
+			count += len(findings)
+		}
+		return count
+	}
+
+	var passes, unknownVersionPasses []*analysis.Pass
+	for _, pkg := range loadTestdata(t, "go122") {
+		pass := newPass(pkg, nil)
+		passes = append(passes, pass)
+
+		// The same package, type checked without a version
+		unknownVersionPass := *pass
+		unknownVersionPass.Module = nil
+		unknownVersionPass.Pkg = types.NewPackage(pkg.Types.Path(), pkg.Types.Name())
+		unknownVersionPass.Pkg.SetImports(pkg.Types.Imports())
+		typesInfo := *pkg.TypesInfo
+		typesInfo.FileVersions = nil
+		unknownVersionPass.TypesInfo = &typesInfo
+		unknownVersionPasses = append(unknownVersionPasses, &unknownVersionPass)
+	}
+
+	if count := countFindings(passes); count != 0 {
+		t.Errorf("got %d findings in code targeting Go 1.22, want none", count)
+	}
+	if count := countFindings(unknownVersionPasses); count != 1 {
+		t.Errorf("got %d findings in code targeting an unknown version, want 1", count)
+	}
+}
+
+// Every kind of capture must have a rule, and kinds past the last rule must be unknown
+func TestRules(t *testing.T) {
+	rules := gotestlooplint.Rules()
+	for i, rule := range rules {
+		if rule.Kind != gotestlooplint.CaptureKind(i) {
+			t.Errorf("rule %d is for kind %v", i, rule.Kind)
+		}
+		if rule.ID != rule.Kind.String() || rule.Description == "" || strings.Count(rule.MessageFormat, "%s") != 2 {
+			t.Errorf("incomplete rule %+v", rule)
+		}
+	}
+
+	if unknown := gotestlooplint.CaptureKind(len(rules)).String(); !strings.HasPrefix(unknown, "CaptureKind(") {
+		t.Errorf("kind %d has no rule but is named %q", len(rules), unknown)
+	}
+}
+
+// Loads the packages of a directory, with their tests, in the given environment
+func loadPackages(tb testing.TB, dir string, env ...string) []*packages.Package {
+	config := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+		Dir:   dir,
+		Env:   append(os.Environ(), env...),
+		Tests: true,
+	}
+
+	pkgs, err := packages.Load(config, ".")
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return pkgs
+}
+
+// Loads the packages of testdata/src/<scenario> in GOPATH mode like analysistest does, or of the testdata/<module>
+// module when the name has a go.mod
+func loadTestdata(tb testing.TB, name string) []*packages.Package {
+	if _, err := os.Stat(filepath.Join(analysistest.TestData(), name, "go.mod")); err == nil {
+		return loadPackages(tb, filepath.Join(analysistest.TestData(), name), "GO111MODULE=on", "GOPROXY=off", "GOWORK=off")
+	}
+
+	return loadGOPATHPackages(tb, analysistest.TestData(), name)
+}
+
+// Loads the packages of <gopath>/src/<name> in GOPATH mode like analysistest does
+func loadGOPATHPackages(tb testing.TB, gopath, name string) []*packages.Package {
+	return loadPackages(tb, filepath.Join(gopath, "src", name), "GOPATH="+gopath, "GO111MODULE=off", "GOWORK=off")
+}
+
+// Returns a pass over a loaded package, of no analyzer in particular
+func newPass(pkg *packages.Package, report func(analysis.Diagnostic)) *analysis.Pass {
+	return &analysis.Pass{
+		Fset:      pkg.Fset,
+		Files:     pkg.Syntax,
+		Pkg:       pkg.Types,
+		TypesInfo: pkg.TypesInfo,
+		ResultOf:  map[*analysis.Analyzer]interface{}{inspect.Analyzer: inspector.New(pkg.Syntax)},
+		Report:    report,
+	}
+}
+
+// Writes a package of the given number of generated tests to a GOPATH of its own. Every test has loops capturing
+// their variables in each kind of subtest closure, next to loops that don't
+func generateTestdata(tb testing.TB, tests int) string {
+	var source strings.Builder
+	source.WriteString("package generated\n\nimport \"testing\"\n")
+	for i := 0; i < tests; i++ {
+		fmt.Fprintf(&source, `
+func TestGenerated%d(t *testing.T) {
+	for i, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			defer func() { _ = i }()
+			go func() { _ = tc }()
+			t.Cleanup(func() { _ = tc })
+			t.Parallel()
+			_ = tc
+		})
+		t.Run(tc, func(t *testing.T) {
+			_ = i
+		})
+	}
+	for i := 0; i < 3; i++ {
+		tc := i
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+		})
+	}
+}
+`, i)
+	}
+
+	gopath := tb.TempDir()
+	dir := filepath.Join(gopath, "src", "generated")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		tb.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "generated_test.go"), []byte(source.String()), 0o644); err != nil {
+		tb.Fatal(err)
+	}
+
+	return gopath
+}
+
+// Runs the checks over a large generated package, loaded once, so that only the analysis itself is measured
+func BenchmarkFindCaptures(b *testing.B) {
+	var passes []*analysis.Pass
+	for _, pkg := range loadGOPATHPackages(b, generateTestdata(b, 1000), "generated") {
+		passes = append(passes, newPass(pkg, nil))
+	}
+
+	findings := 0
+	for _, pass := range passes {
+		passFindings, err := gotestlooplint.FindCaptures(pass)
+		if err != nil {
+			b.Fatal(err)
+		}
+		findings += len(passFindings)
+	}
+	if findings == 0 {
+		b.Fatal("no findings in the generated package")
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, pass := range passes {
+			if _, err := gotestlooplint.FindCaptures(pass); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
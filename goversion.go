@@ -0,0 +1,43 @@
+package gotestlooplint
+
+import (
+	"go/ast"
+	"go/version"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Starting with this version every loop iteration gets its own copy of the loop variables, which makes
+// capturing them in closures safe
+const perIterationLoopVarsGoVersion = "go1.22"
+
+// Returns the Go version the file targets, or an empty string if the driver didn't say, e.g. when the package isn't
+// part of a module or the driver doesn't pass the module information on to the type checker
+func getFileGoVersion(pass *analysis.Pass, file *ast.File) string {
+	// The version the type checker settled on for the file, which already accounts for build constraints and for the
+	// module of the file in workspaces
+	if pass.TypesInfo != nil && pass.TypesInfo.FileVersions[file] != "" {
+		return pass.TypesInfo.FileVersions[file]
+	}
+
+	// A `//go:build go1.xx` constraint overrides the version of the module the file belongs to
+	if file.GoVersion != "" {
+		return file.GoVersion
+	}
+
+	if pass.Pkg == nil {
+		return ""
+	}
+
+	return pass.Pkg.GoVersion()
+}
+
+func hasPerIterationLoopVars(pass *analysis.Pass, file *ast.File) bool {
+	goVersion := getFileGoVersion(pass, file)
+	if !version.IsValid(goVersion) {
+		// The driver didn't tell us which version the code targets, conservatively assume an old one
+		return false
+	}
+
+	return version.Compare(goVersion, perIterationLoopVarsGoVersion) >= 0
+}
@@ -0,0 +1,102 @@
+package gotestlooplint
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Disables the linter for a whole file, e.g. a generated one, when found in a comment before the package clause
+const disableFileDirective = "gotestlooplint:disable-file"
+
+// Checks whether the line of pos carries a `//nolint:gotestlooplint` comment or the ignore directive of the options,
+// `//gotestlooplint:ignore` by default, or whether its file is disabled altogether
+func isIgnored(pass *analysis.Pass, options *Options, pos token.Pos) bool {
+	file := findFile(pass, pos)
+	if file == nil {
+		return false
+	}
+
+	if isFileDisabled(file) {
+		return true
+	}
+
+	line := pass.Fset.Position(pos).Line
+	for _, commentGroup := range file.Comments {
+		for _, comment := range commentGroup.List {
+			if pass.Fset.Position(comment.Pos()).Line == line && isIgnoreDirective(options, comment.Text) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Checks the leading comments of the file, including its doc comment and build constraints, for
+// `//gotestlooplint:disable-file`
+func isFileDisabled(file *ast.File) bool {
+	for _, commentGroup := range file.Comments {
+		if commentGroup.Pos() >= file.Package {
+			break
+		}
+
+		for _, comment := range commentGroup.List {
+			if strings.TrimSpace(strings.TrimPrefix(comment.Text, "//")) == disableFileDirective {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func findFile(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, file := range pass.Files {
+		if file.FileStart <= pos && pos <= file.FileEnd {
+			return file
+		}
+	}
+
+	return nil
+}
+
+func isIgnoreDirective(options *Options, comment string) bool {
+	text, ok := strings.CutPrefix(comment, "//")
+	if !ok {
+		return false
+	}
+
+	text = strings.TrimSpace(text)
+	if options.IgnoreDirective != "" && strings.HasPrefix(text, options.IgnoreDirective) {
+		return true
+	}
+
+	linters, ok := strings.CutPrefix(text, "nolint")
+	if !ok {
+		return false
+	}
+
+	if linters == "" || strings.HasPrefix(linters, " ") {
+		// A bare `//nolint` disables all linters
+		return true
+	}
+
+	linters, ok = strings.CutPrefix(linters, ":")
+	if !ok {
+		return false
+	}
+
+	// Drop explanations such as `//nolint:gotestlooplint // the closure never runs in parallel`. The name is the one
+	// of Analyzer rather than of the pass, as FindCaptures may run as part of another analyzer
+	linters, _, _ = strings.Cut(linters, " ")
+	for _, linter := range strings.Split(linters, ",") {
+		if linter == analyzerName {
+			return true
+		}
+	}
+
+	return false
+}
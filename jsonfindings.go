@@ -0,0 +1,55 @@
+package gotestlooplint
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// jsonFinding is the -json-findings representation of a Finding
+type jsonFinding struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Col      int    `json:"col"`
+	Variable string `json:"variable"`
+	Kind     string `json:"kind"`
+	// ParallelLine is the line of the call associated with the capture, see Finding.CallPos
+	ParallelLine int `json:"parallelLine"`
+	// RangeRole is left out for the variables of for loops, see Finding.RangeRole
+	RangeRole string `json:"rangeRole,omitempty"`
+	// Subtest is left out when the name of the subtest isn't known, see Finding.Subtest
+	Subtest string `json:"subtest,omitempty"`
+}
+
+var (
+	jsonFindingsOutput      io.Writer = os.Stdout
+	jsonFindingsOutputMutex sync.Mutex
+)
+
+// Writes one JSON object per line, packages may be analyzed concurrently so the whole batch is written at once
+func writeJSONFindings(pass *analysis.Pass, findings []Finding) error {
+	jsonFindingsOutputMutex.Lock()
+	defer jsonFindingsOutputMutex.Unlock()
+
+	encoder := json.NewEncoder(jsonFindingsOutput)
+	for _, finding := range findings {
+		position := pass.Fset.Position(finding.Pos)
+		if err := encoder.Encode(jsonFinding{
+			File:         position.Filename,
+			Line:         position.Line,
+			Col:          position.Column,
+			Variable:     finding.Variable,
+			Kind:         finding.Kind.String(),
+			ParallelLine: pass.Fset.Position(finding.CallPos).Line,
+			RangeRole:    finding.RangeRole,
+			Subtest:      finding.Subtest,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
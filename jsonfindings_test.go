@@ -0,0 +1,62 @@
+package gotestlooplint
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/life4/genesis/slices"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// Every finding is written as a JSON object of its own line, with the fields documented for -json-findings
+func TestJSONFindings(t *testing.T) {
+	var output bytes.Buffer
+	jsonFindingsOutput = &output
+	t.Cleanup(func() { jsonFindingsOutput = os.Stdout })
+
+	options := DefaultOptions()
+	options.JSONFindings = true
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(options), "ordering")
+
+	ruleIDs := slices.Map(Rules(), func(rule RuleInfo) string { return rule.ID })
+	lines := bytes.Split(bytes.TrimSpace(output.Bytes()), []byte("\n"))
+	if len(lines) != 5 {
+		t.Fatalf("got %d findings, want 5:\n%s", len(lines), output.String())
+	}
+
+	for _, line := range lines {
+		var finding map[string]interface{}
+		if err := json.Unmarshal(line, &finding); err != nil {
+			t.Fatalf("%s: %v", line, err)
+		}
+
+		for _, field := range []string{"file", "variable", "kind"} {
+			if value, ok := finding[field].(string); !ok || value == "" {
+				t.Errorf("%s: %q isn't a non-empty string", line, field)
+			}
+		}
+
+		for _, field := range []string{"line", "col", "parallelLine"} {
+			if value, ok := finding[field].(float64); !ok || value <= 0 {
+				t.Errorf("%s: %q isn't a positive number", line, field)
+			}
+		}
+
+		for field := range finding {
+			if !slices.Contains([]string{"file", "line", "col", "variable", "kind", "parallelLine", "rangeRole", "subtest"}, field) {
+				t.Errorf("%s: unexpected field %q", line, field)
+			}
+		}
+
+		if file, _ := finding["file"].(string); filepath.Base(file) != "ordering_test.go" {
+			t.Errorf("%s: unexpected file", line)
+		}
+
+		if kind, _ := finding["kind"].(string); !slices.Contains(ruleIDs, kind) {
+			t.Errorf("%s: unknown kind", line)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package gotestlooplint
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/life4/genesis/slices"
+)
+
+// The calls registering closures in a loop body, collected in a single walk of the body and then dispatched to the
+// checks of each kind of call
+type loopCalls struct {
+	// Calls nested inside the arguments of another subtest, benchmark or fuzz call are left out, as they're covered
+	// by the analysis of the outer call
+	subtestCalls      []*ast.CallExpr
+	benchmarkRunCalls []*ast.CallExpr
+	fuzzCalls         []*ast.CallExpr
+
+	// Ginkgo calls are collected at any depth
+	ginkgoSpecCalls         []*ast.CallExpr
+	ginkgoTableCalls        []*ast.CallExpr
+	ginkgoEntryCalls        []*ast.CallExpr
+	ginkgoSetupCalls        []*ast.CallExpr
+	ginkgoContainerCalls    []*ast.CallExpr
+	ginkgoDeferCleanupCalls []*ast.CallExpr
+}
+
+func collectLoopCalls(pass *lintPass, options *Options, loopBody *ast.BlockStmt) loopCalls {
+	var calls loopCalls
+
+	ast.Inspect(loopBody, func(descendantNode ast.Node) bool {
+		callExpression, ok := descendantNode.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		switch {
+		case isTestingCall(pass, callExpression, "T", "Run") || isTestifySuiteCall(pass, callExpression, "Run"):
+			calls.subtestCalls = appendOuterCall(calls.subtestCalls, callExpression)
+		case isTestingCall(pass, callExpression, "B", "Run"):
+			calls.benchmarkRunCalls = appendOuterCall(calls.benchmarkRunCalls, callExpression)
+		case isTestingCall(pass, callExpression, "F", "Fuzz"):
+			calls.fuzzCalls = appendOuterCall(calls.fuzzCalls, callExpression)
+		}
+
+		// Closures registered through a helper are checked like the closures of the specs the helper registers
+		if options.FollowGinkgoHelpers && isGinkgoSpecHelperCall(pass, callExpression) {
+			calls.ginkgoSpecCalls = append(calls.ginkgoSpecCalls, callExpression)
+			return true
+		}
+
+		// Cleanup functions of GinkgoT() are registered through DeferCleanup, so they run once the spec is done
+		if isGinkgoTCall(pass, callExpression, "Cleanup") {
+			calls.ginkgoDeferCleanupCalls = append(calls.ginkgoDeferCleanupCalls, callExpression)
+			return true
+		}
+
+		callIdentifier := getCallIdentifier(callExpression)
+		if callIdentifier == nil || !isGinkgoIdentifier(pass, callIdentifier) {
+			return true
+		}
+
+		switch {
+		case slices.Contains(ginkgoSpecFunctionNames, callIdentifier.Name):
+			calls.ginkgoSpecCalls = append(calls.ginkgoSpecCalls, callExpression)
+		case slices.Contains(ginkgoTableFunctionNames, callIdentifier.Name):
+			calls.ginkgoTableCalls = append(calls.ginkgoTableCalls, callExpression)
+		case slices.Contains(ginkgoEntryFunctionNames, callIdentifier.Name):
+			calls.ginkgoEntryCalls = append(calls.ginkgoEntryCalls, callExpression)
+		case slices.Contains(ginkgoSetupFunctionNames, callIdentifier.Name):
+			calls.ginkgoSetupCalls = append(calls.ginkgoSetupCalls, callExpression)
+		case slices.Contains(ginkgoContainerFunctionNames, callIdentifier.Name):
+			calls.ginkgoContainerCalls = append(calls.ginkgoContainerCalls, callExpression)
+		case slices.Contains(ginkgoDeferCleanupFunctionNames, callIdentifier.Name):
+			calls.ginkgoDeferCleanupCalls = append(calls.ginkgoDeferCleanupCalls, callExpression)
+		}
+
+		return true
+	})
+
+	return calls
+}
+
+// Calls registering the nodes of the spec tree, i.e. every Ginkgo call except DeferCleanup
+func (calls loopCalls) ginkgoNodeCalls() []*ast.CallExpr {
+	return slices.Concat(calls.ginkgoSpecCalls, calls.ginkgoTableCalls, calls.ginkgoEntryCalls, calls.ginkgoSetupCalls, calls.ginkgoContainerCalls)
+}
+
+// Appends the call unless it's nested inside one of the calls already collected. The body is walked in preorder, so
+// outer calls are always collected first
+func appendOuterCall(calls []*ast.CallExpr, call *ast.CallExpr) []*ast.CallExpr {
+	if slices.Any(calls, func(outerCall *ast.CallExpr) bool { return isWithin(call, outerCall) }) {
+		return calls
+	}
+
+	return append(calls, call)
+}
+
+func isWithin(node ast.Node, outerNode ast.Node) bool {
+	return outerNode.Pos() <= node.Pos() && node.End() <= outerNode.End()
+}
+
+func isWithinPos(pos token.Pos, outerNode ast.Node) bool {
+	return outerNode.Pos() <= pos && pos < outerNode.End()
+}
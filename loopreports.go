@@ -0,0 +1,44 @@
+package gotestlooplint
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Reports the calls findCalls finds in the loops of the checked test functions, with the message getMessage returns
+// for each of them. Unlike captures, these calls are bugs regardless of the Go version, so they're not Findings and
+// every loop is visited
+func reportLoopCalls(pass *lintPass, options *Options, findCalls func(loopBody *ast.BlockStmt) []*ast.CallExpr, getMessage func(call *ast.CallExpr) string) {
+	reportedCalls := map[*ast.CallExpr]bool{}
+
+	pass.ResultOf[inspect.Analyzer].(*inspector.Inspector).WithStack([]ast.Node{
+		(*ast.RangeStmt)(nil),
+		(*ast.ForStmt)(nil),
+	}, func(loopNode ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		if functionDeclaration := findEnclosingFunctionDeclaration(stack); functionDeclaration == nil ||
+			!checkFunction(pass, options, functionDeclaration) {
+			return true
+		}
+
+		for _, call := range findCalls(getLoopBody(loopNode)) {
+			// Nested loops see the same call, it's only reported once
+			if reportedCalls[call] {
+				continue
+			}
+			reportedCalls[call] = true
+
+			if !isIgnored(pass.Pass, options, call.Pos()) {
+				pass.Report(analysis.Diagnostic{Pos: call.Pos(), End: call.End(), Message: getMessage(call)})
+			}
+		}
+
+		return true
+	})
+}
@@ -2,6 +2,7 @@ package gotestlooplint
 
 import (
 	"go/ast"
+	"go/token"
 
 	"github.com/life4/genesis/slices"
 )
@@ -17,30 +18,41 @@ func getLoopBody(loopNode ast.Node) *ast.BlockStmt {
 	}
 }
 
-func exprToIdent(expr ast.Expr) *ast.Ident { 
-	switch expr := expr.(type) {
-	case *ast.Ident:
-		return expr
-	case *ast.SelectorExpr:
-		return expr.Sel
-	default:
-		return nil
-	}
+// Only identifiers declare variables, other expressions such as the `h.name` of `for h.name = range ...` assign to
+// existing ones
+func exprToIdent(expr ast.Expr) *ast.Ident {
+	identifier, _ := expr.(*ast.Ident)
+	return identifier
 }
-func isNonNilExpr(expr ast.Expr) bool      { return expr != nil }
 
+func isNonNilExpr(expr ast.Expr) bool { return expr != nil }
+
+// The blank identifier `_` doesn't declare a variable, so there's nothing to capture
+func isNilOrBlankIdent(ident *ast.Ident) bool { return ident == nil || ident.Name == "_" }
+
+// Labeled loops are passed without their *ast.LabeledStmt, and labeled break or continue statements in the body
+// don't change which variables the closures capture
 func getLoopVarsIdentifiers(loopNode ast.Node) []*ast.Ident {
 	switch loopNode := loopNode.(type) {
 	case *ast.ForStmt:
-		// Get A, B, C, ... identifiers from `for A := ..., B := ..., var C ..., ...; ... ; ... { ... }`
-		if loopAssignment, ok := loopNode.Init.(*ast.AssignStmt); ok {
-			return slices.Reject(slices.Map(loopAssignment.Lhs, exprToIdent), func(ident *ast.Ident) bool { return ident == nil })
+		// Get A, B, C, ... identifiers from `for A, B, C := ..., ..., ...; ... ; ... { ... }`. Loops without an init
+		// statement or whose init statement doesn't declare variables, e.g. `for i = 0; ...` reusing an outer
+		// variable, have no loop variables
+		if loopAssignment, ok := loopNode.Init.(*ast.AssignStmt); ok && loopAssignment.Tok == token.DEFINE {
+			return slices.Reject(slices.Map(loopAssignment.Lhs, exprToIdent), isNilOrBlankIdent)
 		}
 		return nil
 	case *ast.RangeStmt:
-		// Get A, B identifiers from `for A, B := range ... { ... }` or A from `for A := range ... { ... }`
-		return slices.Map(slices.Filter([]ast.Expr{loopNode.Key, loopNode.Value}, isNonNilExpr), exprToIdent)
+		// Get A, B identifiers from `for A, B := range ... { ... }` or A from `for A := range ... { ... }`. The
+		// same goes for range-over-int loops like `for A := range 10 { ... }`, which require Go 1.22 and are therefore
+		// only checked with -force or when the driver doesn't tell which version the code targets. Like for loops,
+		// `for A = range ... { ... }` reuses an outer variable and has no loop variables
+		if loopNode.Tok != token.DEFINE {
+			return nil
+		}
+		return slices.Reject(slices.Map(slices.Filter([]ast.Expr{loopNode.Key, loopNode.Value}, isNonNilExpr), exprToIdent), isNilOrBlankIdent)
 	default:
-		panic("unexpected node type")
+		// Only for and range statements declare loop variables, other nodes have none
+		return nil
 	}
 }
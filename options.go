@@ -0,0 +1,136 @@
+package gotestlooplint
+
+import (
+	"flag"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+)
+
+// Options configures the checks of an analyzer created by NewAnalyzer. Every field matches the flag of Analyzer with
+// the same name, e.g. Strict is -strict, and DefaultOptions returns the defaults of those flags
+type Options struct {
+	// Force checks loops even in code targeting Go 1.22 or later
+	Force bool
+	// CheckGoTest checks go tests, benchmarks and fuzz tests using the testing package
+	CheckGoTest bool
+	// CheckGinkgo checks Ginkgo specs
+	CheckGinkgo bool
+	// CheckGoroutine checks the closures subtests run in goroutines, which may run after the loop advanced even if the
+	// subtest isn't parallel. CheckDefer and CheckCleanup check the closures parallel subtests defer or register with
+	// t.Cleanup, when disabled these closures are checked like any other code past t.Parallel()
+	CheckGoroutine bool
+	CheckDefer     bool
+	CheckCleanup   bool
+	// CheckHelpers also checks go tests in helper functions taking a *testing.T
+	CheckHelpers bool
+	// FollowGinkgoHelpers also checks closures passed to same-package helpers that register them as Ginkgo specs
+	FollowGinkgoHelpers bool
+	// IgnoreLogArgs doesn't report loop variables used after t.Parallel() only to be printed by the test context
+	IgnoreLogArgs bool
+	// Strict reports loop variables used anywhere in a parallel subtest, even before t.Parallel(), except for the
+	// aliases the subtest starts with
+	Strict bool
+	// WarnBareParallel also reports t.Parallel() calls made directly in the loops of a test
+	WarnBareParallel bool
+	// WarnSetenvParallel also reports t.Setenv() and t.Chdir() calls in parallel subtests created by loops
+	WarnSetenvParallel bool
+	// JSONFindings also writes every finding to stdout as a JSON object
+	JSONFindings bool
+	// SARIFPath is the file the findings of all analyzed packages are written to as a SARIF 2.1.0 document, if set.
+	// Only the packages analyzed by the same process are written, so the file is overwritten by every package when
+	// each of them is analyzed by a process of its own, e.g. by `go vet -vettool`
+	SARIFPath string
+
+	// IgnoredVariableNames are the names of loop variables that are never reported
+	IgnoredVariableNames []string
+	// TestFunctionPrefixes are the name prefixes of the functions checked as tests
+	TestFunctionPrefixes []string
+	// IgnoreDirective is the comment directive suppressing reports on its line in addition to //nolint:gotestlooplint,
+	// none if empty
+	IgnoreDirective string
+
+	// GoTestMessageFormat and GinkgoMessageFormat override the messages reported for parallel go tests and Ginkgo It
+	// closures, the default message is kept if empty. Both verbs of the format must be %s, they're replaced by the name
+	// of the loop variable. Unlike the default messages, custom ones don't tell the subtest, the range role or the line
+	// of the loop
+	GoTestMessageFormat string
+	GinkgoMessageFormat string
+}
+
+// DefaultOptions returns the options Analyzer runs with when no flag is set
+func DefaultOptions() Options {
+	return Options{
+		CheckGoTest:          true,
+		CheckGinkgo:          true,
+		CheckGoroutine:       true,
+		CheckDefer:           true,
+		CheckCleanup:         true,
+		TestFunctionPrefixes: []string{"Test", "Benchmark", "Fuzz", "Example"},
+		IgnoreDirective:      "gotestlooplint:ignore",
+		GoTestMessageFormat:  goTestFailureMessageFormat,
+		GinkgoMessageFormat:  ginkgoFailureMessageFormat,
+	}
+}
+
+// RegisterFlags defines the flags of Analyzer on the given flag set, bound to the fields of the options and defaulting to
+// their current values. This allows building options from arguments or settings keyed by flag name
+func (options *Options) RegisterFlags(flags *flag.FlagSet) {
+	flags.BoolVar(&options.Force, "force", options.Force, "check loops even in code targeting Go 1.22 or later, where loop variables are per-iteration")
+	flags.BoolVar(&options.CheckGoTest, "check-gotest", options.CheckGoTest, "check go tests, benchmarks and fuzz tests using the testing package")
+	flags.BoolVar(&options.CheckGinkgo, "check-ginkgo", options.CheckGinkgo, "check Ginkgo specs")
+	flags.BoolVar(&options.CheckGoroutine, "check-goroutine", options.CheckGoroutine, "check closures subtests run in goroutines, including HTTP handlers and the errgroup and sync.WaitGroup functions of parallel subtests")
+	flags.BoolVar(&options.CheckDefer, "check-defer", options.CheckDefer, "check closures deferred by parallel subtests")
+	flags.BoolVar(&options.CheckCleanup, "check-cleanup", options.CheckCleanup, "check t.Cleanup closures of parallel subtests")
+	flags.BoolVar(&options.CheckHelpers, "check-helpers", options.CheckHelpers, "also check go tests in helper functions taking a *testing.T, not only in test functions")
+	flags.BoolVar(&options.FollowGinkgoHelpers, "follow-ginkgo-helpers", options.FollowGinkgoHelpers, "also check closures passed to same-package helpers that forward them to a Ginkgo It or Specify call")
+	flags.BoolVar(&options.JSONFindings, "json-findings", options.JSONFindings, "also write every finding to stdout as a JSON object, including its kind and the line of the associated call")
+	flags.BoolVar(&options.IgnoreLogArgs, "ignore-log-args", options.IgnoreLogArgs, "don't report loop variables used after t.Parallel() only to be printed by t.Log, t.Logf, t.Error, t.Errorf, t.Fatal or t.Fatalf")
+	flags.BoolVar(&options.Strict, "strict", options.Strict, "report loop variables used anywhere in a parallel subtest, even before t.Parallel()")
+	flags.BoolVar(&options.WarnBareParallel, "warn-bare-parallel", options.WarnBareParallel, "also report t.Parallel() calls made directly in the loops of a test rather than in its subtests")
+	flags.BoolVar(&options.WarnSetenvParallel, "warn-setenv-parallel", options.WarnSetenvParallel, "also report t.Setenv() and t.Chdir() calls in parallel subtests created by loops, which panic")
+	flags.Var(appendFlag{&options.IgnoredVariableNames}, "ignore-var", "name of a loop variable to never report, can be repeated")
+	flags.Var(appendFlag{&options.TestFunctionPrefixes}, "test-prefix", "additional name prefix of functions to check as tests, can be repeated")
+	flags.StringVar(&options.SARIFPath, "sarif", options.SARIFPath, "also write the findings of all packages analyzed by this process to this file as a SARIF 2.1.0 document, not supported by go vet -vettool")
+	flags.StringVar(&options.IgnoreDirective, "ignore-directive", options.IgnoreDirective, "comment directive suppressing reports on its line, in addition to //nolint:gotestlooplint")
+	flags.Var(messageFormatFlag{&options.GoTestMessageFormat}, "gotest-message", "format of the message reported for parallel go tests, must contain two %s verbs for the loop variable name. Reported as is, without the subtest and loop details of the default message")
+	flags.Var(messageFormatFlag{&options.GinkgoMessageFormat}, "ginkgo-message", "format of the message reported for Ginkgo It closures, must contain two %s verbs for the loop variable name. Reported as is, without the loop details of the default message")
+}
+
+// The name of the analyzers, which is also the name //nolint directives refer to
+const analyzerName = "gotestlooplint"
+
+// The options of Analyzer, set through its flags
+var analyzerOptions = DefaultOptions()
+
+// NewAnalyzer returns an analyzer running the same checks as Analyzer, configured by the given options rather than by
+// flags. Options are copied, so changing them afterwards doesn't affect the analyzer, and the analyzer has no flags
+func NewAnalyzer(options Options) *analysis.Analyzer {
+	options.IgnoredVariableNames = append([]string(nil), options.IgnoredVariableNames...)
+	options.TestFunctionPrefixes = append([]string(nil), options.TestFunctionPrefixes...)
+
+	return newAnalyzer(&options)
+}
+
+func newAnalyzer(options *Options) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name: analyzerName,
+		Doc:  "gotestlooplint looks for loop var capture in parallel go tests or for loop var capture in regular Ginkgo tests",
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			return findIgnoredTests(newLintPass(pass), options)
+		},
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+}
+
+// Returns the format of the message reported for captures of the given kind
+func (options *Options) getMessageFormat(kind CaptureKind) string {
+	switch {
+	case kind == CaptureParallel && options.GoTestMessageFormat != "":
+		return options.GoTestMessageFormat
+	case kind == CaptureGinkgoSpec && options.GinkgoMessageFormat != "":
+		return options.GinkgoMessageFormat
+	default:
+		return getMessageFormat(kind)
+	}
+}
@@ -0,0 +1,48 @@
+// Package main is a golangci-lint plugin, build it with `go build -buildmode=plugin`
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/omertuc/gotestlooplint"
+	"golang.org/x/tools/go/analysis"
+)
+
+// New is the entry point of golangci-lint plugins. conf holds the linter settings from the golangci-lint
+// configuration, keyed by the analyzer flag names, e.g. `check-ginkgo: false` or `gotest-message: "..."`. Every call
+// returns an analyzer of its own, gotestlooplint.Analyzer and its flags are left alone
+func New(conf any) ([]*analysis.Analyzer, error) {
+	options := gotestlooplint.DefaultOptions()
+	if conf != nil {
+		settings, ok := conf.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("gotestlooplint settings must be a map, got %T", conf)
+		}
+
+		flags := flag.NewFlagSet("gotestlooplint", flag.ContinueOnError)
+		options.RegisterFlags(flags)
+		for name, value := range settings {
+			if flags.Lookup(name) == nil {
+				return nil, fmt.Errorf("unknown gotestlooplint setting %q", name)
+			}
+
+			// Repeatable flags such as test-prefix are given as lists
+			values, ok := value.([]any)
+			if !ok {
+				values = []any{value}
+			}
+
+			for _, value := range values {
+				if err := flags.Set(name, fmt.Sprint(value)); err != nil {
+					return nil, fmt.Errorf("invalid gotestlooplint setting %q: %w", name, err)
+				}
+			}
+		}
+	}
+
+	return []*analysis.Analyzer{gotestlooplint.NewAnalyzer(options)}, nil
+}
+
+// Plugins are loaded by golangci-lint, main only keeps `go build ./...` working
+func main() {}
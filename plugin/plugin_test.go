@@ -0,0 +1,49 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/omertuc/gotestlooplint"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestNew(t *testing.T) {
+	analyzers, err := New(map[string]any{
+		"check-ginkgo": false,
+		"test-prefix":  []any{"Scenario"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(analyzers) != 1 || analyzers[0] == gotestlooplint.Analyzer {
+		t.Fatalf("got %v, want a new analyzer", analyzers)
+	}
+
+	testdata, err := filepath.Abs(filepath.Join("..", "testdata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	analysistest.Run(t, testdata, analyzers[0], "testprefix", "noginkgo")
+
+	// The settings only apply to the analyzer of the plugin
+	for _, name := range []string{"check-ginkgo", "test-prefix"} {
+		if value := gotestlooplint.Analyzer.Flags.Lookup(name).Value.String(); value != gotestlooplint.Analyzer.Flags.Lookup(name).DefValue {
+			t.Errorf("-%s of Analyzer changed to %q", name, value)
+		}
+	}
+}
+
+func TestNewInvalidSettings(t *testing.T) {
+	for _, conf := range []any{
+		[]string{"check-ginkgo"},
+		map[string]any{"check-everything": true},
+		map[string]any{"check-ginkgo": "sometimes"},
+		map[string]any{"gotest-message": "loop variable %s"},
+	} {
+		if _, err := New(conf); err == nil {
+			t.Errorf("%v: no error", conf)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package gotestlooplint
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/life4/genesis/slices"
+	"golang.org/x/tools/go/analysis"
+)
+
+// A minimal subset of SARIF 2.1.0, see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID           string          `json:"ruleId"`
+	Level            string          `json:"level"`
+	Message          sarifMessage    `json:"message"`
+	Locations        []sarifLocation `json:"locations"`
+	RelatedLocations []sarifLocation `json:"relatedLocations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	ID               int                   `json:"id,omitempty"`
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	Message          *sarifMessage         `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+var (
+	// The results written so far to each path, analyzers created by NewAnalyzer may write to different ones
+	sarifResults      = map[string][]sarifResult{}
+	sarifResultsMutex sync.Mutex
+)
+
+// Packages are analyzed one pass at a time with no hook once all of them are done, so the results of every pass are
+// accumulated and the whole document is rewritten after each pass. The results only live as long as the process, so
+// drivers running every package in a process of its own, like `go vet -vettool`, leave the last package's alone
+func writeSARIF(pass *analysis.Pass, findings []Finding, path string) error {
+	sarifResultsMutex.Lock()
+	defer sarifResultsMutex.Unlock()
+
+	// SARIF requires results to be an array even if there are none
+	results := sarifResults[path]
+	if results == nil {
+		results = []sarifResult{}
+	}
+
+	for _, finding := range findings {
+		results = append(results, sarifResult{
+			RuleID:    finding.Kind.String(),
+			Level:     "warning",
+			Message:   sarifMessage{finding.Message()},
+			Locations: []sarifLocation{getSARIFLocation(pass, finding.Pos, 0, nil)},
+			RelatedLocations: []sarifLocation{
+				getSARIFLocation(pass, finding.CallPos, 1, &sarifMessage{"associated call"}),
+				getSARIFLocation(pass, finding.DeclarationPos, 2, &sarifMessage{fmt.Sprintf("`%s` is declared here", finding.Variable)}),
+			},
+		})
+	}
+	sarifResults[path] = results
+
+	document, err := json.MarshalIndent(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           analyzerName,
+				InformationURI: "https://github.com/omertuc/gotestlooplint",
+				Rules: slices.Map(Rules(), func(rule RuleInfo) sarifRule {
+					return sarifRule{ID: rule.ID, ShortDescription: sarifMessage{rule.Description}}
+				}),
+			}},
+			Results: results,
+		}},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, document, 0o644)
+}
+
+// Related locations are identified by a positive id, the main location has none
+func getSARIFLocation(pass *analysis.Pass, pos token.Pos, id int, message *sarifMessage) sarifLocation {
+	position := pass.Fset.Position(pos)
+	return sarifLocation{
+		ID: id,
+		PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: getSARIFURI(position.Filename)},
+			Region:           sarifRegion{StartLine: position.Line, StartColumn: position.Column},
+		},
+		Message: message,
+	}
+}
+
+// Code scanning resolves URIs relative to the repository root, which is usually the working directory of the linter
+func getSARIFURI(filename string) string {
+	if workingDirectory, err := os.Getwd(); err == nil {
+		if relativeFilename, err := filepath.Rel(workingDirectory, filename); err == nil && !strings.HasPrefix(relativeFilename, "..") {
+			return filepath.ToSlash(relativeFilename)
+		}
+	}
+
+	return filepath.ToSlash(filename)
+}
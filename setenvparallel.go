@@ -0,0 +1,36 @@
+package gotestlooplint
+
+import (
+	"fmt"
+	"go/ast"
+
+	"github.com/life4/genesis/slices"
+)
+
+// Methods of *testing.T changing the state of the whole process, which panic in parallel tests
+var processStateMethodNames = []string{"Setenv", "Chdir"}
+
+const setenvParallelMessageFormat = "t.%s() called in a parallel subtest. It panics, as it changes the state of the whole process which the other parallel subtests of the loop share"
+
+// Reports t.Setenv() and t.Chdir() calls in the parallel subtests created by the loops of a test
+func reportSetenvParallelCalls(pass *lintPass, options *Options) {
+	reportLoopCalls(pass, options, func(loopBody *ast.BlockStmt) []*ast.CallExpr {
+		var calls []*ast.CallExpr
+		for _, runCall := range findAllSubtestCalls(pass, loopBody) {
+			closure := getSubtestClosure(pass, runCall)
+			if closure == nil || isParallelFunctionClosure(pass, closure) == nil {
+				continue
+			}
+
+			isOwnCall := getOwnCallFilter(pass, closure)
+			for _, methodName := range processStateMethodNames {
+				calls = append(calls, slices.Filter(findAllTestingTCalls(pass, closure.Body, methodName), isOwnCall)...)
+			}
+		}
+
+		return calls
+	}, func(call *ast.CallExpr) string {
+		// Only calls of the methods named by processStateMethodNames are found
+		return fmt.Sprintf(setenvParallelMessageFormat, call.Fun.(*ast.SelectorExpr).Sel.Name)
+	})
+}
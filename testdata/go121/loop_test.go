@@ -0,0 +1,13 @@
+package go121
+
+import "testing"
+
+// Reported since the module targets Go 1.21
+func TestLoop(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+}
@@ -0,0 +1,15 @@
+//go:build go1.22
+
+package go121
+
+import "testing"
+
+// Targets Go 1.22 even though the module targets Go 1.21
+func TestNewer(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package go122
+
+import "testing"
+
+// Skipped since every iteration has its own copy of the loop variables from Go 1.22
+func TestLoop(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+		})
+	}
+}
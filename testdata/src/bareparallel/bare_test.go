@@ -0,0 +1,23 @@
+package bareparallel
+
+import "testing"
+
+// t.Parallel() called in the loop itself rather than in a subtest
+func TestBare(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Parallel() // want "t.Parallel\\(\\) called directly in a loop instead of in a subtest"
+		_ = tc
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			t.Parallel() // want "t.Parallel\\(\\) called directly in a loop instead of in a subtest"
+		}
+	}
+	for _, tc := range []string{"a", "b"} {
+		tc := tc
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package benchmark
+
+import "testing"
+
+// Captures in b.RunParallel, but not in the b.Run closure it's called from
+func BenchmarkX(b *testing.B) {
+	for _, tc := range []string{"a", "b"} {
+		b.Run(tc, func(b *testing.B) {
+			_ = tc
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_ = tc // want "loop variable `tc` used directly inside parallel benchmark closure"
+				}
+			})
+		})
+	}
+}
+
+// Every benchmark of the loop and every b.RunParallel call of its closure
+func BenchmarkSeveral(b *testing.B) {
+	for _, tc := range []string{"a", "b"} {
+		b.Run("first", func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_ = tc // want "loop variable `tc` used directly inside parallel benchmark closure"
+				}
+			})
+		})
+		b.Run("second", func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_ = tc // want "loop variable `tc` used directly inside parallel benchmark closure"
+				}
+			})
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_ = tc // want "loop variable `tc` used directly inside parallel benchmark closure"
+				}
+			})
+		})
+	}
+}
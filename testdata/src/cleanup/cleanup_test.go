@@ -0,0 +1,23 @@
+package cleanup
+
+import "testing"
+
+// t.Cleanup closures may run after the loop advanced
+func TestCleanup(t *testing.T) {
+	for _, tc := range []string{"a"} {
+		t.Run(tc, func(t *testing.T) {
+			// Runs before t.Run returns
+			t.Cleanup(func() {
+				_ = tc
+			})
+		})
+	}
+	for _, tc := range []string{"a"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			t.Cleanup(func() {
+				_ = tc // want "loop variable `tc` captured inside t.Cleanup closure"
+			})
+		})
+	}
+}
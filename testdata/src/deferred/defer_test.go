@@ -0,0 +1,25 @@
+package deferred
+
+import "testing"
+
+// Closures deferred by subtests
+func TestDefer(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run("x", func(t *testing.T) {
+			// Runs before t.Run returns
+			defer func() {
+				_ = tc
+			}()
+			_ = tc
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run("x", func(t *testing.T) {
+			defer func() {
+				_ = tc // want "loop variable `tc` captured inside deferred closure"
+			}()
+			_ = tc
+			t.Parallel()
+		})
+	}
+}
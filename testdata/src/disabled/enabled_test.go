@@ -0,0 +1,15 @@
+package disabled
+
+import "testing"
+
+//gotestlooplint:disable-file
+
+// Reported as the disable directive comes after the package clause
+func TestEnabled(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+}
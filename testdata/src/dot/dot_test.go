@@ -0,0 +1,13 @@
+package dot
+
+import . "testing"
+
+// The testing package imported with a dot
+func TestDot(t *T) {
+	for _, tc := range []string{"a"} {
+		t.Run(tc, func(t *T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel"
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package findings
+
+import "testing"
+
+// A capture of each kind of subtest closure
+func TestFindings(t *testing.T) {
+	for _, tc := range []string{"a"} {
+		t.Run(tc, func(t *testing.T) {
+			defer func() {
+				_ = tc // want "^defer: loop variable `tc`"
+			}()
+			go func() {
+				_ = tc // want "^goroutine: loop variable `tc`"
+			}()
+			t.Cleanup(func() {
+				_ = tc // want "^cleanup: loop variable `tc`"
+			})
+			t.Parallel()
+			_ = tc // want "^parallel: loop variable `tc`"
+		})
+	}
+}
+
+// A capture in a parallel benchmark closure
+func BenchmarkFindings(b *testing.B) {
+	for _, tc := range []string{"a"} {
+		b.Run(tc, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				_ = tc // want "^benchmark: loop variable `tc`"
+			})
+		})
+	}
+}
+
+// A capture in a fuzz target
+func FuzzFindings(f *testing.F) {
+	for _, tc := range []string{"a"} {
+		f.Fuzz(func(t *testing.T, s string) {
+			_ = tc // want "^fuzz: loop variable `tc`"
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package findings
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+)
+
+// A capture of each kind of Ginkgo closure
+var _ = Describe("findings", func() {
+	for _, tc := range []string{"a"} {
+		It(tc, func() {
+			_ = tc // want "^ginkgo: loop variable `tc`"
+		})
+		DescribeTable(tc, func(s string) {
+			_ = tc // want "^ginkgo-table: loop variable `tc`"
+		}, Entry(tc, func() {
+			_ = tc // want "^ginkgo-entry: loop variable `tc`"
+		}))
+		BeforeEach(func() {
+			_ = tc // want "^ginkgo-setup: loop variable `tc`"
+		})
+		Context(tc, func() {
+			_ = tc // want "^ginkgo-container: loop variable `tc`"
+		})
+	}
+
+	BeforeEach(func() {
+		for _, tc := range []string{"a"} {
+			DeferCleanup(func() {
+				_ = tc // want "^ginkgo-defer-cleanup: loop variable `tc`"
+			})
+		}
+	})
+})
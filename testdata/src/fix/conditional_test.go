@@ -0,0 +1,22 @@
+package fix
+
+import "testing"
+
+// Subtests calling t.Parallel() conditionally are fixed by a single alias in the loop body
+func TestFixConditional(t *testing.T) {
+	parallel := true
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			if parallel {
+				t.Parallel()
+			}
+			_ = tc // want "loop variable `tc` used directly inside parallel"
+		})
+		t.Run(tc, func(t *testing.T) {
+			_ = tc // want "loop variable `tc` used directly inside parallel"
+			if parallel {
+				t.Parallel()
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package fix
+
+import "testing"
+
+// Aliases every captured loop variable
+func TestFix(t *testing.T) {
+	for k, v := range map[string]int{"a": 1} {
+		t.Run(k, func(t *testing.T) {
+			t.Parallel()
+			_ = k // want "loop variable `k` used directly inside parallel"
+			_ = v // want "loop variable `v` used directly inside parallel"
+		})
+	}
+}
+
+// A loop variable used twice is only aliased once
+func TestFixRepeated(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = i // want "loop variable `i` used directly inside parallel"
+			_ = i // want "loop variable `i` used directly inside parallel"
+		})
+	}
+}
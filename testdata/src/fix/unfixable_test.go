@@ -0,0 +1,34 @@
+package fix
+
+import "testing"
+
+// Captures an alias can't fix without redeclaring a variable or changing what the loop does aren't fixed
+func TestUnfixable(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			tc := tc // want "loop variable `tc` used directly inside parallel"
+			_ = tc
+		})
+	}
+	parallel := true
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			if parallel {
+				t.Parallel()
+			}
+			_ = tc // want "loop variable `tc` used directly inside parallel"
+		})
+		tc := "done"
+		_ = tc
+	}
+	for i := 0; i < 3; i++ {
+		t.Run("x", func(t *testing.T) {
+			if parallel {
+				t.Parallel()
+			}
+			_ = i // want "loop variable `i` used directly inside parallel"
+		})
+		i++
+	}
+}
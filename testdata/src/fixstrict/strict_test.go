@@ -0,0 +1,14 @@
+package fixstrict
+
+import "testing"
+
+// With -strict, captures are fixed by an alias in the loop body, even those before t.Parallel()
+func TestFixStrict(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			name := tc // want "loop variable `tc` used directly inside parallel"
+			t.Parallel()
+			_, _ = name, tc // want "loop variable `tc` used directly inside parallel"
+		})
+	}
+}
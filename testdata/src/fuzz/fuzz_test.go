@@ -0,0 +1,13 @@
+package fuzz
+
+import "testing"
+
+// The fuzz target runs after the loop ended
+func FuzzX(f *testing.F) {
+	for i, seed := range []string{"a", "b"} {
+		f.Add(seed)
+		f.Fuzz(func(t *testing.T, s string) {
+			_ = i // want "loop variable `i` used directly inside fuzz closure"
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package ginkgo
+
+import . "github.com/onsi/ginkgo/v2"
+
+// By callbacks run right away
+var _ = Describe("by", func() {
+	BeforeEach(func() {
+		for _, tc := range []string{"a", "b"} {
+			By("step "+tc, func() {
+				_ = tc
+			})
+		}
+	})
+})
@@ -0,0 +1,22 @@
+package ginkgo
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+// Containers and the nodes they register within a loop
+func TestContainer(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		Context("with "+tc, func() {
+			name := tc // want "loop variable `tc` used directly inside ginkgo container closure"
+			When(name, func() {
+				_ = tc // want "loop variable `tc` used directly inside ginkgo container closure"
+			})
+			BeforeEach(func() {
+				_ = tc // want "loop variable `tc` used directly inside ginkgo setup or teardown closure"
+			})
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package ginkgo
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+// The body of a spec comes after its decorators
+func TestDecorated(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		It("x", Label("y"), FlakeAttempts(3), func() {
+			_ = tc // want "loop variable `tc` used directly inside ginkgo It closure"
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package ginkgo
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+// DeferCleanup closures registered within a loop, with and without arguments
+var _ = Describe("defer cleanup", func() {
+	BeforeEach(func() {
+		for _, file := range []string{"a", "b"} {
+			DeferCleanup(func() {
+				_ = os.Remove(file) // want "loop variable `file` captured inside ginkgo DeferCleanup closure"
+			})
+			DeferCleanup(func(name string) {
+				_ = os.Remove(name)
+				_ = file // want "loop variable `file` captured inside ginkgo DeferCleanup closure"
+			}, file)
+			DeferCleanup(os.Remove, file)
+		}
+	})
+
+	for _, tc := range []string{"a", "b"} {
+		It("x", func() {
+			DeferCleanup(func() {
+				_ = tc // want "loop variable `tc` used directly inside ginkgo It closure"
+			})
+		})
+	}
+})
@@ -0,0 +1,23 @@
+package ginkgo
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+)
+
+// Table entries built within a loop, with a body or only parameters
+var _ = Describe("entries", func() {
+	var entries []TableEntry
+	for _, tc := range []string{"a", "b"} {
+		entries = append(entries,
+			Entry(tc, func() {
+				_ = tc // want "loop variable `tc` used directly inside ginkgo table entry closure"
+			}),
+			FEntry(tc, func(s string) {
+				_ = s
+				_ = tc // want "loop variable `tc` used directly inside ginkgo table entry closure"
+			}, tc),
+			Entry(tc, tc),
+		)
+	}
+	DescribeTable("table", func(s string) {}, entries)
+})
@@ -0,0 +1,26 @@
+package ginkgo
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+)
+
+// Focused and pending specs
+func TestFocus(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		ginkgo.FIt("x", func() {
+			_ = tc // want "loop variable `tc` used directly inside ginkgo It closure"
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		ginkgo.PIt("x", func() {
+			_ = tc // want "loop variable `tc` used directly inside ginkgo It closure"
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		ginkgo.XIt("x", func() {
+			_ = tc // want "loop variable `tc` used directly inside ginkgo It closure"
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package ginkgo
+
+import (
+	"github.com/life4/genesis/slices"
+	. "github.com/onsi/ginkgo/v2"
+)
+
+// Entries built by a slices.Map callback, which gets every case as a parameter of its own call
+var _ = DescribeTable("functional entries", func(name string) {},
+	slices.Map([]string{"a", "b"}, func(name string) TableEntry {
+		return Entry(name, func() {
+			_ = name
+		})
+	}),
+)
+
+// Specs registered by a slices.Each callback
+var _ = Describe("functional specs", func() {
+	slices.Each([]string{"a", "b"}, func(name string) {
+		It(name, func() {
+			_ = name
+		})
+	})
+})
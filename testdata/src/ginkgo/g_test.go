@@ -0,0 +1,17 @@
+package ginkgo
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+// A spec with a body and a pending one without
+func TestG(t *testing.T) {
+	for _, tc := range []string{"a"} {
+		It("x", func() {
+			_ = tc // want "loop variable `tc` used directly inside ginkgo It closure"
+		})
+		It("pending " + tc)
+	}
+}
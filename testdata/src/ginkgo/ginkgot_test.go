@@ -0,0 +1,27 @@
+package ginkgo
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+)
+
+// GinkgoT() cleanups and parallel calls
+var _ = Describe("ginkgo t", func() {
+	for _, tc := range []string{"a", "b"} {
+		It("x", func() {
+			GinkgoT().Parallel()
+			GinkgoT().Log(tc) // want "loop variable `tc` used directly inside ginkgo It closure"
+		})
+	}
+
+	BeforeEach(func() {
+		for _, file := range []string{"a", "b"} {
+			GinkgoT().Cleanup(func() {
+				_ = file // want "loop variable `file` captured inside ginkgo DeferCleanup closure"
+			})
+			t := GinkgoT()
+			t.Cleanup(func() {
+				_ = file // want "loop variable `file` captured inside ginkgo DeferCleanup closure"
+			})
+		}
+	})
+})
@@ -0,0 +1,29 @@
+package ginkgo
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+)
+
+// Specs nested in containers created by a loop
+var _ = Describe("looped containers", func() {
+	for _, tc := range []string{"a", "b"} {
+		Describe("with "+tc, func() {
+			It("x", func() {
+				_ = tc // want "loop variable `tc` used directly inside ginkgo It closure"
+			})
+			When("nested", func() {
+				It("y", func() {
+					_ = tc // want "loop variable `tc` used directly inside ginkgo It closure"
+				})
+			})
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		tc := tc
+		Describe("with "+tc, func() {
+			It("x", func() {
+				_ = tc
+			})
+		})
+	}
+})
@@ -0,0 +1,19 @@
+package ginkgo
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+// Every spec of the loop body is reported
+func TestMultipleIt(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		It("first", func() {
+			_ = tc // want "loop variable `tc` used directly inside ginkgo It closure"
+		})
+		It("second", func() {
+			_ = tc // want "loop variable `tc` used directly inside ginkgo It closure"
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package ginkgo
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+// Setup and teardown nodes registered within a loop
+func TestSetup(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		BeforeEach(func() {
+			_ = tc // want "loop variable `tc` used directly inside ginkgo setup or teardown closure"
+		})
+		JustAfterEach(func() {
+			_ = tc // want "loop variable `tc` used directly inside ginkgo setup or teardown closure"
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package ginkgo
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+// An alias in the loop body is safe for specs too
+func TestShadow(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		tc := tc
+		It("x", func() {
+			_ = tc
+		})
+	}
+}
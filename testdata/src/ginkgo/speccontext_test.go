@@ -0,0 +1,23 @@
+package ginkgo
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+// Spec bodies taking a context
+var _ = Describe("spec context", func() {
+	for _, tc := range []string{"a", "b"} {
+		It("x", func(ctx SpecContext) {
+			<-ctx.Done()
+			_ = tc // want "loop variable `tc` used directly inside ginkgo It closure"
+		}, NodeTimeout(10))
+	}
+	for _, tc := range []string{"a", "b"} {
+		It("x", Label("y"), func(ctx context.Context) {
+			_ = ctx
+			_ = tc // want "loop variable `tc` used directly inside ginkgo It closure"
+		}, NodeTimeout(10))
+	}
+})
@@ -0,0 +1,16 @@
+package ginkgo
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+// Specify is an alias of It
+func TestSpecify(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		Specify("x", func() {
+			_ = tc // want "loop variable `tc` used directly inside ginkgo It closure"
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package ginkgo
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+)
+
+// Table entries and the body of a table built within a loop
+func TestTable(t *testing.T) {
+	var entries []ginkgo.TableEntry
+	for _, tc := range []string{"a", "b"} {
+		entries = append(entries, ginkgo.Entry(tc, func() {
+			_ = tc // want "loop variable `tc` used directly inside ginkgo table entry closure"
+		}))
+	}
+	for _, tc := range []string{"a", "b"} {
+		ginkgo.DescribeTable(tc, func(s string) {
+			_ = tc // want "loop variable `tc` used directly inside ginkgo table closure"
+		}, ginkgo.Entry("x", "y"), entries)
+	}
+}
@@ -0,0 +1,33 @@
+package ginkgohelpers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+)
+
+func registerSpec(description string, body func()) {
+	It(description, body)
+}
+
+func registerDecoratedSpec(description string, args ...interface{}) {
+	Specify(description, args...)
+}
+
+func describe(description string, body func()) {
+	_ = description
+	body()
+}
+
+// Specs registered through helpers forwarding their body to ginkgo, with -follow-ginkgo-helpers
+var _ = Describe("helpers", func() {
+	for _, tc := range []string{"a", "b"} {
+		registerSpec("x", func() {
+			_ = tc // want "loop variable `tc` used directly inside ginkgo It closure"
+		})
+		registerDecoratedSpec("y", Label("z"), func() {
+			_ = tc // want "loop variable `tc` used directly inside ginkgo It closure"
+		})
+		describe("z", func() {
+			_ = tc
+		})
+	}
+})
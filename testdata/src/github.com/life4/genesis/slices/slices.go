@@ -0,0 +1,5 @@
+package slices
+
+func Each[S ~[]T, T any](items S, f func(el T)) {}
+
+func Map[S ~[]T, T any, G any](items S, f func(el T) G) []G { return nil }
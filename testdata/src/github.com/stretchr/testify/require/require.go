@@ -0,0 +1,5 @@
+package require
+
+import "testing"
+
+func Equal(t *testing.T, expected, actual interface{}, msgAndArgs ...interface{}) {}
@@ -0,0 +1,13 @@
+package suite
+
+import "testing"
+
+type Suite struct {
+	t *testing.T
+}
+
+func (suite *Suite) T() *testing.T { return suite.t }
+
+func (suite *Suite) Run(name string, subtest func()) bool { return true }
+
+func Run(t *testing.T, suite interface{}) {}
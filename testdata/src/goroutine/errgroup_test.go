@@ -0,0 +1,44 @@
+package goroutine
+
+import (
+	"testing"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// errgroup goroutines may outlive the subtest
+func TestErrgroup(t *testing.T) {
+	for i := range []string{"a", "b"} {
+		t.Run("x", func(t *testing.T) {
+			// The subtest waits for the group before the loop advances
+			var g errgroup.Group
+			g.Go(func() error {
+				_ = i
+				return nil
+			})
+			_ = g.Wait()
+		})
+	}
+	for i := range []string{"a", "b"} {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			g := &errgroup.Group{}
+			g.Go(func() error {
+				_ = i // want "loop variable `i` captured inside goroutine"
+				return nil
+			})
+			_ = g.Wait()
+		})
+	}
+	for i := range []string{"a", "b"} {
+		t.Run("x", func(t *testing.T) {
+			j := i
+			var g errgroup.Group
+			g.Go(func() error {
+				_ = j
+				return nil
+			})
+			_ = g.Wait()
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package goroutine
+
+import "testing"
+
+// Goroutines may outlive the subtest even when it isn't parallel
+func TestGoroutine(t *testing.T) {
+	for i := range []string{"a", "b"} {
+		t.Run("x", func(t *testing.T) {
+			done := make(chan struct{})
+			go func() {
+				_ = i // want "loop variable `i` captured inside goroutine"
+				close(done)
+			}()
+			<-done
+		})
+	}
+	for i := range []string{"a", "b"} {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			go func(j int) {
+				_ = i // want "loop variable `i` captured inside goroutine"
+			}(i) // want "loop variable `i` used directly inside parallel"
+		})
+	}
+	for i := range []string{"a", "b"} {
+		t.Run("x", func(t *testing.T) {
+			_ = i
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package goroutine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// HTTP handlers run whenever a request comes in
+func TestHTTPHandler(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tc)) // want "loop variable `tc` captured inside HTTP handler registered by test closure"
+			}))
+			defer server.Close()
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			mux := http.NewServeMux()
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				_ = tc // want "loop variable `tc` captured inside HTTP handler registered by test closure"
+			})
+			http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				_ = tc // want "loop variable `tc` captured inside HTTP handler registered by test closure"
+			})
+		})
+	}
+	// A subtest that isn't parallel is done with its server before the loop advances
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tc))
+			}))
+			defer server.Close()
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		tc := tc
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tc))
+			}))
+			defer server.Close()
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package goroutine
+
+import (
+	"sync"
+	"testing"
+)
+
+// sync.Once runs right away, while goroutines the subtest waits for through a WaitGroup, whether they're launched by
+// its Go method or call its Done method, only outlive the iteration in parallel subtests
+func TestSync(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			var once sync.Once
+			t.Parallel()
+			once.Do(func() {
+				_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+			})
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			var once sync.Once
+			once.Do(func() {
+				_ = tc
+			})
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = tc
+			}()
+			wg.Wait()
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = tc // want "loop variable `tc` captured inside goroutine"
+			}()
+			wg.Wait()
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = tc // want "loop variable `tc` captured inside goroutine"
+			}()
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			wg := &sync.WaitGroup{}
+			wg.Go(func() {
+				_ = tc
+			})
+			wg.Wait()
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			wg := &sync.WaitGroup{}
+			wg.Go(func() {
+				_ = tc // want "loop variable `tc` captured inside goroutine"
+			})
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			wg := &sync.WaitGroup{}
+			wg.Go(func() {
+				_ = tc // want "loop variable `tc` captured inside goroutine"
+			})
+			wg.Wait()
+		})
+	}
+}
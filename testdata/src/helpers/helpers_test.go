@@ -0,0 +1,24 @@
+package helpers
+
+import "testing"
+
+// Reported with -check-helpers as it takes a *testing.T
+func runCases(t *testing.T, cases []string) {
+	for _, tc := range cases {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+}
+
+// Not a helper since it doesn't take a *testing.T
+func notAHelper(cases []string) {
+	var t *testing.T
+	for _, tc := range cases {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package ignorevar
+
+import (
+	"context"
+	"testing"
+)
+
+// ctx is ignored by -ignore-var
+func TestIgnoreVar(t *testing.T) {
+	for _, ctx := range []context.Context{context.Background()} {
+		for _, tc := range []string{"a", "b"} {
+			t.Run(tc, func(t *testing.T) {
+				t.Parallel()
+				_ = ctx
+				_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+			})
+		}
+	}
+}
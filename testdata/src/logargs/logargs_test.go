@@ -0,0 +1,16 @@
+package logargs
+
+import "testing"
+
+// Arguments of t.Logf() aren't reported with -ignore-log-args
+func TestLogArgs(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			t.Logf("case %s", tc)
+			if tc == "" { // want "loop variable `tc` used directly inside parallel test closure"
+				t.Errorf("empty case %s", tc)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package message
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+func TestMessage(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "`tc` is shared by the subtests of the loop, see https://wiki\\.example\\.com/loopvar#tc$"
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		It(tc, func() {
+			_ = tc // want "`tc` is shared by the specs of the loop, alias `tc` first$"
+		})
+	}
+}
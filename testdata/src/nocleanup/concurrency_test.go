@@ -0,0 +1,21 @@
+package nocleanup
+
+import "testing"
+
+// t.Cleanup closures are treated as the rest of the subtest with -check-cleanup=false
+func TestConcurrency(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			go func() {
+				_ = tc // want "loop variable `tc` captured inside goroutine"
+			}()
+			defer func() {
+				_ = tc // want "loop variable `tc` captured inside deferred closure"
+			}()
+			t.Cleanup(func() {
+				_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+			})
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package nodefer
+
+import "testing"
+
+// Deferred closures are treated as the rest of the subtest with -check-defer=false
+func TestConcurrency(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			go func() {
+				_ = tc // want "loop variable `tc` captured inside goroutine"
+			}()
+			defer func() {
+				_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+			}()
+			t.Cleanup(func() {
+				_ = tc // want "loop variable `tc` captured inside t.Cleanup closure"
+			})
+		})
+	}
+}
+
+// Deferred closures still run after t.Parallel() when they're deferred before it
+func TestDeferredBeforeParallel(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			defer func() {
+				_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+			}()
+			t.Parallel()
+		})
+	}
+}
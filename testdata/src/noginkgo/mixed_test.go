@@ -0,0 +1,21 @@
+package noginkgo
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+func TestMixed(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		It(tc, func() {
+			_ = tc
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package nogotest
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+func TestMixed(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		It(tc, func() {
+			_ = tc // want "loop variable `tc` used directly inside ginkgo It closure"
+		})
+	}
+}
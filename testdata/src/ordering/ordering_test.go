@@ -0,0 +1,25 @@
+package ordering
+
+import "testing"
+
+// Several kinds of captures in the same subtests, reported in source order
+func TestOrdering(t *testing.T) {
+	for i, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = i // want "loop variable `i`"
+			go func() {
+				_ = tc // want "loop variable `tc` captured inside goroutine"
+			}()
+			_ = tc // want "loop variable `tc` used directly"
+		})
+		t.Run(tc, func(t *testing.T) {
+			defer func() {
+				_ = i // want "loop variable `i` captured inside deferred closure"
+			}()
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly"
+			_ = i  //nolint:gotestlooplint
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package parallel
+
+import "testing"
+
+// A loop using its variable in a parallel subtest is reported, the loop without subtests next to it isn't
+func TestX(t *testing.T) {
+	cases := []string{"a", "b"}
+	for _, tc := range cases {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly"
+		})
+	}
+	for _, tc := range cases {
+		_ = tc
+	}
+}
@@ -0,0 +1,25 @@
+package parallel
+
+import "testing"
+
+type suiteT struct {
+	t *testing.T
+}
+
+// Captures through an alias of the test context or a field holding it
+func TestAlias(t *testing.T) {
+	tt := t
+	for _, tc := range []string{"a", "b"} {
+		tt.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+	s := suiteT{t: t}
+	for _, tc := range []string{"a", "b"} {
+		s.t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package parallel
+
+import "testing"
+
+// Loops without variables
+func TestBlank(t *testing.T) {
+	xs := []int{1}
+	for _, v := range xs {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = v // want "loop variable `v` used directly inside parallel"
+		})
+	}
+	for _ = range xs {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = xs
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package parallel
+
+import "testing"
+
+// Range over a channel
+func TestChannel(t *testing.T) {
+	ch := make(chan string)
+	for v := range ch {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = v // want "loop variable `v` used directly inside parallel test closure.*`v` is the range value$"
+		})
+	}
+	for range ch {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+		})
+	}
+}
+
+// Range over iterator functions
+func TestIterator(t *testing.T) {
+	seq := func(yield func(string) bool) {}
+	for v := range seq {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = v // want "`v` is the range value$"
+		})
+	}
+	seq2 := func(yield func(int, string) bool) {}
+	for k, v := range seq2 {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = k // want "`k` is the range key$"
+			_ = v // want "`v` is the range value$"
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package parallel
+
+import "testing"
+
+// Every use is reported when t.Parallel() is called conditionally
+func TestConditionalParallel(t *testing.T) {
+	parallel := true
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+			if parallel {
+				t.Parallel()
+			}
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			_ = tc
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+}
+
+// Aliases the subtest starts with run before t.Parallel() wherever it's called, later ones don't
+func TestConditionalParallelAlias(t *testing.T) {
+	parallel := true
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			tc := tc
+			if parallel {
+				t.Parallel()
+			}
+			_ = tc
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			name := "x"
+			tc := tc // want "loop variable `tc` used directly inside parallel test closure"
+			if parallel {
+				t.Parallel()
+			}
+			_, _ = name, tc
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package parallel
+
+import (
+	"context"
+	"testing"
+)
+
+type ctxKey struct{}
+
+// Context values copy the loop variable unless they point to it
+func TestContextValue(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct{ name string }{{"a"}, {"b"}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.WithValue(ctx, ctxKey{}, tc)
+			t.Parallel()
+			_ = ctx.Value(ctxKey{})
+		})
+	}
+	for _, tc := range []struct{ name string }{{"a"}, {"b"}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.WithValue(ctx, ctxKey{}, &tc) // want "loop variable `tc` used directly inside parallel"
+			t.Parallel()
+			_ = ctx.Value(ctxKey{})
+		})
+	}
+}
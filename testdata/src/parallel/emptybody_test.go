@@ -0,0 +1,14 @@
+package parallel
+
+import "testing"
+
+// Subtests without statements
+func TestEmptyBody(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {})
+	}
+	for i := 0; i < 2; i++ {
+		t.Run("x", func(t *testing.T) {
+		})
+	}
+}
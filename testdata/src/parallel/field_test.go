@@ -0,0 +1,33 @@
+package parallel
+
+import "testing"
+
+type fieldSuite struct {
+	t *testing.T
+}
+
+type embeddingSuite struct {
+	*testing.T
+}
+
+// Subtests created through a field holding the test context
+func TestField(t *testing.T) {
+	s := &fieldSuite{t: t}
+	for _, tc := range []string{"a", "b"} {
+		s.t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+}
+
+// Subtests created through an embedded test context
+func TestEmbeddedField(t *testing.T) {
+	s := embeddingSuite{t}
+	for _, tc := range []string{"a", "b"} {
+		s.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package parallel
+
+import "testing"
+
+// t.Parallel() as the first statement, including single line subtests
+func TestParallelFirstStatement(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) { t.Parallel(); _ = tc }) // want "loop variable `tc` used directly inside parallel test closure"
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) { _ = tc; t.Parallel() })
+	}
+	for _, tc := range []int{1, 2} {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = func(n [2]int) int { return n[tc] } // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package parallel
+
+import "testing"
+
+// Only variables declared by the init statement are loop variables
+func TestForInit(t *testing.T) {
+	for i, j := 0, 10; i < j; i, j = i+1, j-1 {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = i // want "loop variable `i` used directly inside parallel test closure"
+			_ = j // want "loop variable `j` used directly inside parallel test closure"
+		})
+	}
+	for i, j, k := 0, 10, 5; i < j; i++ {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = k // want "loop variable `k` used directly inside parallel test closure"
+		})
+	}
+	n := 0
+	for ; n < 3; n++ {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = n
+		})
+	}
+	for n = 0; n < 3; n++ {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = n
+		})
+	}
+	for n < 6 {
+		n++
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = n
+		})
+	}
+}
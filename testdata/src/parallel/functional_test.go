@@ -0,0 +1,17 @@
+package parallel
+
+import (
+	"testing"
+
+	"github.com/life4/genesis/slices"
+)
+
+// Subtests created by a slices.Each callback, which gets every case as a parameter of its own call
+func TestFunctional(t *testing.T) {
+	slices.Each([]string{"a", "b"}, func(tc string) {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+		})
+	})
+}
@@ -0,0 +1,29 @@
+package parallel
+
+import "testing"
+
+func parallelize(t *testing.T) {
+	t.Helper()
+	t.Parallel()
+}
+
+func logName(t *testing.T) {
+	t.Log(t.Name())
+}
+
+// Helpers calling t.Parallel() make the subtest parallel
+func TestHelper(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			_ = tc
+			parallelize(t)
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			logName(t)
+			_ = tc
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package parallel
+
+import "testing"
+
+// Indexing with the loop variable
+func TestIndex(t *testing.T) {
+	items := []string{"a", "b"}
+	for i := range items {
+		t.Run(items[i], func(t *testing.T) {
+			t.Parallel()
+			_ = items[i] // want "loop variable `i` used directly inside parallel test closure"
+		})
+	}
+	for i := 0; i < len(items); i++ {
+		t.Run(items[i], func(t *testing.T) {
+			t.Parallel()
+			item := items[i] // want "loop variable `i` used directly inside parallel test closure"
+			_ = item
+		})
+	}
+	for i := range items {
+		i := i
+		t.Run(items[i], func(t *testing.T) {
+			t.Parallel()
+			_ = items[i]
+		})
+	}
+}
+
+var indexCases = []struct{ name string }{{"a"}, {"b"}}
+
+// Indexing a table declared outside the test
+func TestIndexOuterTable(t *testing.T) {
+	for i := range indexCases {
+		t.Run(indexCases[i].name, func(t *testing.T) {
+			t.Parallel()
+			_ = indexCases[i].name // want "loop variable `i` used directly inside parallel test closure"
+		})
+	}
+}
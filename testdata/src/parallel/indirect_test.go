@@ -0,0 +1,26 @@
+package parallel
+
+import "testing"
+
+type indirectCase struct {
+	name  string
+	items []int
+}
+
+// Addresses, composite literals and indexes of the loop variable
+func TestIndirect(t *testing.T) {
+	for _, tc := range []indirectCase{{name: "a", items: []int{1}}} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			p := &tc                           // want "loop variable `tc` used directly inside parallel"
+			cfg := indirectCase{name: tc.name} // want "loop variable `tc` used directly inside parallel"
+			_, _ = p, cfg
+		})
+	}
+	for _, tc := range [][]int{{1}} {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = tc[0] // want "loop variable `tc` used directly inside parallel"
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package parallel
+
+import "testing"
+
+type stepsCase struct {
+	name  string
+	steps []string
+}
+
+// Loops inside the subtest ranging over the loop variable
+func TestInnerLoop(t *testing.T) {
+	for _, tc := range []stepsCase{{"a", []string{"x"}}} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			for _, step := range tc.steps { // want "loop variable `tc` used directly inside parallel test closure"
+				_ = step
+				_ = tc.name // want "loop variable `tc` used directly inside parallel test closure"
+			}
+		})
+	}
+}
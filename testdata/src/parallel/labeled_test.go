@@ -0,0 +1,30 @@
+package parallel
+
+import "testing"
+
+// Labeled loops
+func TestLabeled(t *testing.T) {
+Outer:
+	for _, tc := range []string{"a", "b"} {
+		for i := 0; i < 2; i++ {
+			if i == 1 {
+				continue Outer
+			}
+			t.Run(tc, func(t *testing.T) {
+				t.Parallel()
+				_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+				_ = i  // want "loop variable `i` used directly inside parallel test closure"
+			})
+		}
+	}
+Cases:
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+		if tc == "" {
+			break Cases
+		}
+	}
+}
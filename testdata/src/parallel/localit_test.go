@@ -0,0 +1,14 @@
+package parallel
+
+import "testing"
+
+// A local function named It isn't ginkgo, and neither are builtins
+func TestLocalIt(t *testing.T) {
+	It := func(name string, f func()) {}
+	for _, tc := range []string{"a", "b"} {
+		It(tc, func() {
+			_ = tc
+		})
+		_ = len(tc)
+	}
+}
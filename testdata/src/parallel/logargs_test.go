@@ -0,0 +1,13 @@
+package parallel
+
+import "testing"
+
+// Arguments of t.Logf() are reported by default
+func TestLogArgs(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			t.Logf("case %s", tc) // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+}
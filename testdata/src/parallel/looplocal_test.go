@@ -0,0 +1,15 @@
+package parallel
+
+import "testing"
+
+// Variables declared in the loop body are per iteration
+func TestLoopLocal(t *testing.T) {
+	for _, name := range []string{"a", "b"} {
+		tc := struct{ N string }{name}
+		t.Run(tc.N, func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+			_ = name // want "loop variable `name` used directly inside parallel test closure"
+		})
+	}
+}
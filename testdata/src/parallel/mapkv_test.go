@@ -0,0 +1,22 @@
+package parallel
+
+import "testing"
+
+// Several loop variables used in the same expression
+func TestMapKeyValue(t *testing.T) {
+	for k, v := range map[string]int{"a": 1} {
+		t.Run(k, func(t *testing.T) {
+			t.Parallel()
+			if k != "" && v > 0 { // want "loop variable `k` used directly inside parallel test closure" "loop variable `v` used directly inside parallel test closure"
+				_ = map[string]int{k: v} // want "loop variable `k` used directly inside parallel test closure" "loop variable `v` used directly inside parallel test closure"
+			}
+		})
+	}
+	for i, j := 0, 0; i < 2; i++ {
+		t.Run("x", func(t *testing.T) {
+			p := &struct{ a, b int }{i, j}
+			t.Parallel()
+			_ = []int{p.a, i + j} // want "loop variable `i` used directly inside parallel test closure" "loop variable `j` used directly inside parallel test closure"
+		})
+	}
+}
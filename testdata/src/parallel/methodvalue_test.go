@@ -0,0 +1,23 @@
+package parallel
+
+import "testing"
+
+type methodCase struct{ name string }
+
+func (c methodCase) run(t *testing.T) {
+	t.Parallel()
+	_ = c.name
+}
+
+// Method values are bound when t.Run is called
+func TestMethodValue(t *testing.T) {
+	for _, tc := range []methodCase{{"a"}, {"b"}} {
+		t.Run(tc.name, tc.run)
+	}
+	for _, tc := range []methodCase{{"a"}, {"b"}} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.run(t) // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+}
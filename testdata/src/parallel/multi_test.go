@@ -0,0 +1,20 @@
+package parallel
+
+import "testing"
+
+// Several subtests in the same loop body
+func TestMulti(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run("first", func(t *testing.T) {
+			_ = tc
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel"
+		})
+		t.Run("second", func(t *testing.T) {
+			_ = tc
+			_ = tc
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel"
+		})
+	}
+}
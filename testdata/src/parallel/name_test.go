@@ -0,0 +1,22 @@
+package parallel
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Uses in the arguments of t.Run itself aren't reported
+func TestName(t *testing.T) {
+	for _, tc := range []struct{ name string }{{"a"}} {
+		t.Run(func() string { return tc.name }(), func(t *testing.T) {
+			_ = tc.name
+			t.Parallel()
+		})
+	}
+	for i, tc := range []int{1, 2} {
+		t.Run(fmt.Sprintf("%d-%d", i, tc), func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package parallel
+
+import "testing"
+
+func makeSubtest(tc string) func(t *testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		_ = tc
+	}
+}
+
+// Subtests given as a variable rather than a literal
+func TestNamed(t *testing.T) {
+	for _, tc := range []string{"a"} {
+		t.Run(tc, makeSubtest(tc))
+	}
+	for _, tc := range []string{"a"} {
+		fn := func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel"
+		}
+		t.Run(tc, fn)
+	}
+	for _, tc := range []string{"a"} {
+		var fn = func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel"
+		}
+		t.Run(tc, fn)
+	}
+}
+
+// Variables assigned another function aren't followed
+func TestNamedReassigned(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		fn := func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+		}
+		if tc == "b" {
+			fn = func(t *testing.T) {}
+		}
+		t.Run(tc, fn)
+	}
+	for _, tc := range []string{"a", "b"} {
+		fn := func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		}
+		t.Run(tc, fn)
+	}
+}
@@ -0,0 +1,27 @@
+package parallel
+
+import "testing"
+
+// Parallel subtests nested in another subtest of the loop
+func TestNested(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run("outer", func(t *testing.T) {
+			_ = tc
+			t.Parallel()
+			t.Run("inner", func(t *testing.T) {
+				t.Parallel()
+				_ = tc // want "loop variable `tc` used directly inside parallel"
+			})
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run("outer", func(t *testing.T) {
+			_ = tc
+			t.Run("inner", func(t *testing.T) {
+				t.Parallel()
+				_ = tc
+			})
+			_ = tc
+		})
+	}
+}
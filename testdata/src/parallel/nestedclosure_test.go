@@ -0,0 +1,53 @@
+package parallel
+
+import "testing"
+
+// Closures declared before t.Parallel() and called after it
+func TestNestedClosure(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			check := func() {
+				_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+			}
+			value := tc
+			t.Parallel()
+			check()
+			_ = value
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			check := func() {
+				_ = tc
+			}
+			check()
+		})
+	}
+}
+
+// Closures called right away run before t.Parallel(), unless they're deferred
+func TestInvokedClosure(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			name := func() string {
+				return tc
+			}()
+			func() {
+				_ = tc
+			}()
+			t.Parallel()
+			_ = name
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			defer func() {
+				_ = tc // want "loop variable `tc` captured inside deferred closure"
+			}()
+			t.Parallel()
+			func() {
+				_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+			}()
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package parallel
+
+import "testing"
+
+// Variables of both the inner and the outer loop
+func TestNestedLoops(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			t.Run("x", func(t *testing.T) {
+				t.Parallel()
+				_ = i // want "loop variable `i` used directly inside parallel test closure"
+				_ = j // want "loop variable `j` used directly inside parallel test closure"
+			})
+		}
+	}
+	for _, outer := range []string{"a", "b"} {
+		for _, inner := range []string{"a", "b"} {
+			inner := inner
+			t.Run(inner, func(t *testing.T) {
+				t.Parallel()
+				_ = inner
+				_ = outer // want "loop variable `outer` used directly inside parallel test closure"
+			})
+		}
+	}
+}
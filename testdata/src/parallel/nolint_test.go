@@ -0,0 +1,28 @@
+package parallel
+
+import "testing"
+
+// Ignore directives on the loop or on the line of the use
+func TestNolint(t *testing.T) {
+	for _, tc := range []string{"a"} { //nolint:gotestlooplint
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+		})
+	}
+	for _, tc := range []string{"a"} { //gotestlooplint:ignore
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+		})
+	}
+	for _, tc := range []string{"a"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc //nolint:errcheck,gotestlooplint // safe
+			_ = tc // nolint:errcheck // want "loop variable `tc` used directly inside parallel"
+			_ = tc //gotestlooplint:ignore
+			_ = tc //nolint
+		})
+	}
+}
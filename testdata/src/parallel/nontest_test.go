@@ -0,0 +1,49 @@
+package parallel
+
+import "testing"
+
+type runner struct{}
+
+func (runner) Run(name string, f func(t *testing.T)) {}
+
+// Not a test function, nor is runner a testing type
+func runAll(r runner) {
+	for _, tc := range []string{"a", "b"} {
+		r.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+		})
+	}
+}
+
+// Not a test function
+func helperRun(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+		})
+	}
+}
+
+type suite struct{}
+
+// A method isn't a test function
+func (suite) TestMethod(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+		})
+	}
+}
+
+// Loops outside of function declarations are still checked
+var _ = func(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel"
+		})
+	}
+}
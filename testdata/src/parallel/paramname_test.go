@@ -0,0 +1,20 @@
+package parallel
+
+import "testing"
+
+// The parameter of the subtest isn't named t
+func TestParamName(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(st *testing.T) {
+			st.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+			t.Log("outer")
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(st *testing.T) {
+			_ = tc
+			st.Parallel()
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package parallel
+
+import "testing"
+
+type pointerCase struct{ name string }
+
+// Addresses of the loop variable are reported even before t.Parallel()
+func TestPointer(t *testing.T) {
+	for _, tc := range []pointerCase{{"a"}, {"b"}} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &tc           // want "loop variable `tc` used directly inside parallel test closure"
+			name := &(tc.name) // want "loop variable `tc` used directly inside parallel test closure"
+			value := tc
+			t.Parallel()
+			_, _, _ = *p, *name, value
+		})
+	}
+	for _, tc := range []pointerCase{{"a"}, {"b"}} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &tc
+			_ = p
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package parallel
+
+import "testing"
+
+// Range over an integer, and ranges assigning to an index expression
+func TestRangeInt(t *testing.T) {
+	for i := range 10 {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = i // want "loop variable `i` used directly inside parallel"
+		})
+	}
+	for _, v := range []int{1} {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = v // want "loop variable `v` used directly inside parallel"
+		})
+	}
+	var arr [1]int
+	for arr[0] = range []int{1} {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = arr
+		})
+	}
+}
+
+type holder struct{ name string }
+
+func TestRangeAssign(t *testing.T) {
+	var h, other holder
+	m := map[string]int{"a": 1}
+	for h.name = range m {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = other.name
+			_ = h.name
+		})
+	}
+	var k string
+	for k = range m {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = k
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package parallel
+
+import "testing"
+
+// Messages tell whether the variable is the range key or value
+func TestRangeRole(t *testing.T) {
+	for i := range []string{"a", "b"} {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = i // want "loop variable `i` used directly inside parallel test closure.*`i` is the range key$"
+		})
+	}
+	for _, v := range []string{"a", "b"} {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = v // want "loop variable `v` used directly inside parallel test closure.*`v` is the range value$"
+		})
+	}
+	for i, v := range []string{"a", "b"} {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			_ = i // want "`i` is the range key$"
+			_ = v // want "`v` is the range value$"
+		})
+	}
+	for i := 0; i < 2; i++ {
+		t.Run(t.Name(), func(t *testing.T) {
+			t.Parallel()
+			_ = i // want "Try aliasing `i` to a variable outside the closure$"
+		})
+	}
+}
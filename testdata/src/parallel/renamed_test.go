@@ -0,0 +1,17 @@
+package parallel
+
+import tst "testing"
+
+// The testing package imported under another name
+func TestRenamed(t *tst.T) {
+	for _, tc := range []string{"a"} {
+		t.Run(tc, func(t *tst.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel"
+		})
+		(&tst.T{}).Run(tc, func(t *tst.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel"
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package parallel
+
+import "testing"
+
+// Helpers are only checked with -check-helpers
+func runCases(t *testing.T, cases []string) {
+	for _, tc := range cases {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+		})
+	}
+}
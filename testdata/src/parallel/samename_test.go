@@ -0,0 +1,25 @@
+package parallel
+
+import "testing"
+
+// Messages tell which loop declared a variable shadowed by a nested one
+func TestSameName(t *testing.T) {
+	for i := range []string{"a", "b"} {
+		t.Run("outer", func(t *testing.T) {
+			t.Parallel()
+			for i := 0; i < 2; i++ {
+				t.Run("inner", func(t *testing.T) {
+					t.Parallel()
+					_ = i // want "`i` is the variable of the loop on line 10$"
+				})
+			}
+			_ = i // want "`i` is the range key of the loop on line 7$"
+		})
+	}
+	for i := range []string{"a", "b"} {
+		t.Run("single", func(t *testing.T) {
+			t.Parallel()
+			_ = i // want "`i` is the range key$"
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package parallel
+
+import "testing"
+
+// Aliases shadowing the loop variable, in the loop body or before t.Parallel(), are safe
+func TestShadow(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		tc := tc
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run("x", func(t *testing.T) {
+			tc := tc
+			t.Parallel()
+			_ = tc
+			go func() {
+				_ = tc
+			}()
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range []string{"c", "d"} {
+				_ = tc
+			}
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run("x", func(t *testing.T) {
+			t.Parallel()
+			tc := tc // want "loop variable `tc` used directly inside parallel"
+			_ = tc
+		})
+	}
+}
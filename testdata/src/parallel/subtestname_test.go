@@ -0,0 +1,31 @@
+package parallel
+
+import (
+	"fmt"
+	"testing"
+)
+
+const subtestName = "constant name"
+
+// Messages tell the name of the subtest unless it's computed
+func TestSubtestName(t *testing.T) {
+	for _, tc := range []struct{ name string }{{"a"}, {"b"}} {
+		t.Run("valid input", func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "outside the closure in subtest \"valid input\". `tc` is the range value$"
+		})
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "outside the closure in subtest tc.name. `tc` is the range value$"
+		})
+		t.Run(subtestName, func(t *testing.T) {
+			go func() {
+				_ = tc // want "captured inside goroutine .* in subtest \"constant name\""
+			}()
+		})
+		t.Run(fmt.Sprint(tc), func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "outside the closure. `tc` is the range value$"
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package parallel
+
+import "testing"
+
+// Loops nested in a parallel group subtest
+func TestThreeLevels(t *testing.T) {
+	t.Run("group", func(t *testing.T) {
+		t.Parallel()
+		for _, tc := range []string{"a", "b"} {
+			t.Run(tc, func(t *testing.T) {
+				t.Parallel()
+				_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+			})
+		}
+	})
+	for _, group := range []string{"a", "b"} {
+		t.Run(group, func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range []string{"c", "d"} {
+				t.Run(tc, func(t *testing.T) {
+					t.Parallel()
+					_ = tc    // want "loop variable `tc` used directly inside parallel test closure"
+					_ = group // want "loop variable `group` used directly inside parallel test closure"
+				})
+			}
+		})
+	}
+}
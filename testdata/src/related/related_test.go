@@ -0,0 +1,19 @@
+package related
+
+import "testing"
+
+func TestRelated(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+	for i := 0; i < 2; i++ {
+		t.Run("x", func(t *testing.T) {
+			_ = i
+			t.Parallel()
+			_ = i // want "loop variable `i` used directly inside parallel test closure"
+		})
+	}
+}
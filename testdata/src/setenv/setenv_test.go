@@ -0,0 +1,28 @@
+package setenv
+
+import "testing"
+
+// t.Setenv() and t.Chdir() in parallel subtests, but not in their parent
+func TestSetenv(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Setenv("CASE", "x") // want `t.Setenv\(\) called in a parallel subtest`
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			t.Chdir(tc) // want `t.Chdir\(\) called in a parallel subtest` "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			t.Setenv("CASE", tc)
+			t.Run("nested", func(t *testing.T) {
+				t.Parallel()
+			})
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package stored
+
+import "testing"
+
+type storedHolder struct{ callback func() }
+
+// Closures stored for later rather than called within the iteration
+func TestStoredClosures(t *testing.T) {
+	var fns []func()
+	for _, tc := range []string{"a", "b"} {
+		fns = append(fns, func() {
+			_ = tc // want "loop variable `tc` captured inside closure stored for later by the loop"
+		})
+	}
+	for _, fn := range fns {
+		t.Run("x", func(t *testing.T) {
+			fn()
+		})
+	}
+
+	callbacks := map[string]func(){}
+	holder := &storedHolder{}
+	var last func()
+	for i, tc := range []string{"a", "b"} {
+		callbacks[tc] = func() {
+			_ = i // want "loop variable `i` captured inside closure stored for later by the loop"
+		}
+		holder.callback = func() {
+			_ = tc // want "loop variable `tc` captured inside closure stored for later by the loop"
+		}
+		last = (func() {
+			_ = tc // want "loop variable `tc` captured inside closure stored for later by the loop"
+		})
+	}
+	_ = last
+
+	for _, tc := range []string{"a", "b"} {
+		tc := tc
+		fns = append(fns, func() {
+			_ = tc
+		})
+		local := func() {
+			_ = tc
+		}
+		local()
+		func() {
+			_ = tc
+		}()
+	}
+	for _, tc := range []string{"a", "b"} {
+		fn := func() {
+			_ = tc
+		}
+		fn()
+	}
+}
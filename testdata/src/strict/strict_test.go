@@ -0,0 +1,38 @@
+package strict
+
+import "testing"
+
+// Uses before t.Parallel() are reported with -strict
+func TestStrict(t *testing.T) {
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			name := tc // want "loop variable `tc` used directly inside parallel test closure"
+			t.Parallel()
+			_ = name
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			_ = tc
+		})
+	}
+}
+
+// Aliases the subtest starts with aren't reported with -strict either
+func TestStrictAlias(t *testing.T) {
+	for k, v := range map[string]int{"a": 1} {
+		t.Run(k, func(t *testing.T) {
+			k, v := k, v
+			t.Parallel()
+			_, _ = k, v
+		})
+	}
+	for i, tc := range []string{"a", "b"} {
+		t.Run(tc, func(t *testing.T) {
+			tc := tc
+			name := i // want "loop variable `i` used directly inside parallel test closure"
+			t.Parallel()
+			_, _ = name, tc
+		})
+	}
+}
@@ -0,0 +1,3 @@
+package tags
+
+var cases = []string{"a", "b"}
@@ -0,0 +1,15 @@
+//go:build integration
+
+package tags
+
+import "testing"
+
+// Only built with the integration tag
+func TestIntegration(t *testing.T) {
+	for _, tc := range integrationCases {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package tags
+
+import "testing"
+
+// Built without tags
+func TestTagged(t *testing.T) {
+	for _, tc := range cases {
+		t.Run(tc, func(t *testing.T) {
+			t.Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package testify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type requireCase struct{ input, expected string }
+
+// Arguments of testify assertions
+func TestRequire(t *testing.T) {
+	for _, tc := range []requireCase{{"a", "a"}} {
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+			got := tc.input                    // want "loop variable `tc` used directly inside parallel test closure"
+			require.Equal(t, tc.expected, got) // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+}
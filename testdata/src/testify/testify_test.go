@@ -0,0 +1,36 @@
+package testify
+
+import "github.com/stretchr/testify/suite"
+
+type CasesSuite struct {
+	suite.Suite
+}
+
+// Subtests of a testify suite
+func (s *CasesSuite) TestCases() {
+	for _, tc := range []string{"a", "b"} {
+		s.Run(tc, func() {
+			s.T().Parallel()
+			_ = tc // want "loop variable `tc` used directly inside parallel test closure"
+		})
+	}
+	for _, tc := range []string{"a", "b"} {
+		s.Run(tc, func() {
+			_ = tc
+		})
+	}
+}
+
+type notASuite struct{}
+
+func (n *notASuite) Run(name string, f func()) {}
+
+// Not a method of a testify suite
+func (n *notASuite) TestCases(s *CasesSuite) {
+	for _, tc := range []string{"a", "b"} {
+		s.Run(tc, func() {
+			s.T().Parallel()
+			_ = tc
+		})
+	}
+}